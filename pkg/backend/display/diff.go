@@ -27,6 +27,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v2/engine"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/graph"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
@@ -58,6 +59,7 @@ func ShowDiffEvents(op string, action apitype.UpdateKind,
 	}()
 
 	seen := make(map[resource.URN]engine.StepEventMetadata)
+	statsStates := make(map[resource.URN]*resource.State)
 
 	for {
 		select {
@@ -75,6 +77,15 @@ func ShowDiffEvents(op string, action apitype.UpdateKind,
 			}
 
 			msg := RenderDiffEvent(action, event, seen, opts)
+			if opts.ShowStats {
+				switch event.Type {
+				case engine.ResourcePreEvent:
+					recordGraphStatsState(statsStates, event.Payload().(engine.ResourcePreEventPayload).Metadata)
+				case engine.SummaryEvent:
+					msg += renderGraphStats(statsStates, opts)
+					msg += renderDiffStats(event.Payload().(engine.SummaryEventPayload).DiffStats, opts)
+				}
+			}
 			if msg != "" && out != nil {
 				fprintIgnoreError(out, msg)
 			}
@@ -236,6 +247,60 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 	return out.String()
 }
 
+// recordGraphStatsState tracks the state that a step leaves behind so that graph statistics can be rendered once all
+// steps have been seen.
+func recordGraphStatsState(states map[resource.URN]*resource.State, step engine.StepEventMetadata) {
+	switch step.Op {
+	case deploy.OpDelete, deploy.OpReadDiscard:
+		delete(states, step.URN)
+	case deploy.OpDeleteReplaced, deploy.OpDiscardReplaced:
+		// The replacement's state has already been recorded by the step that created it.
+	default:
+		if step.New != nil && step.New.State != nil {
+			states[step.URN] = step.New.State
+		}
+	}
+}
+
+// renderGraphStats renders statistics about the dependency graph formed by the given resource states.
+func renderGraphStats(states map[resource.URN]*resource.State, opts Options) string {
+	resources := make([]*resource.State, 0, len(states))
+	for _, state := range states {
+		resources = append(resources, state)
+	}
+	stats := graph.NewDependencyGraph(resources).Stats()
+
+	out := &bytes.Buffer{}
+	fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%sGraph:%s\n", colors.SpecHeadline, colors.Reset)))
+	fprintfIgnoreError(out, "    %d total %s\n", stats.Resources, english.PluralWord(stats.Resources, "resource", ""))
+	fprintfIgnoreError(out, "    %d max dependency depth\n", stats.MaxDepth)
+	fprintfIgnoreError(out, "    %d widest independent layer\n", stats.MaxWidth)
+	fprintfIgnoreError(out, "    %d %s with no dependents\n",
+		stats.Leaves, english.PluralWord(stats.Leaves, "resource", ""))
+	return out.String()
+}
+
+// renderDiffStats renders the work that the planner performed comparing resource inputs, if it was recorded.
+func renderDiffStats(stats *deploy.DiffStatsSummary, opts Options) string {
+	if stats == nil {
+		return ""
+	}
+
+	out := &bytes.Buffer{}
+	fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%sDiffs:%s\n", colors.SpecHeadline, colors.Reset)))
+	fprintfIgnoreError(out, "    %d %s compared\n",
+		stats.Resources, english.PluralWord(stats.Resources, "resource", ""))
+	fprintfIgnoreError(out, "    %d property %s, %d %s\n",
+		stats.Total.Comparisons, english.PluralWord(stats.Total.Comparisons, "comparison", ""),
+		stats.Total.Recursions, english.PluralWord(stats.Total.Recursions, "recursion", ""))
+	if stats.Costliest != "" {
+		fprintfIgnoreError(out, "    costliest: %s (%d property %s, %d %s)\n", stats.Costliest,
+			stats.CostliestStats.Comparisons, english.PluralWord(stats.CostliestStats.Comparisons, "comparison", ""),
+			stats.CostliestStats.Recursions, english.PluralWord(stats.CostliestStats.Recursions, "recursion", ""))
+	}
+	return out.String()
+}
+
 func renderPolicyPacks(out io.Writer, policyPacks map[string]string, opts Options) {
 	if len(policyPacks) == 0 {
 		return
@@ -305,19 +370,29 @@ func renderDiff(
 	seen map[resource.URN]engine.StepEventMetadata,
 	opts Options) {
 
+	metadata = truncateStepProperties(metadata, opts.MaxValueLength)
 	indent := engine.GetIndent(metadata, seen)
 	summary := engine.GetResourcePropertiesSummary(metadata, indent)
+	if opts.ShowDependencies {
+		summary += engine.GetResourceDependenciesSummary(metadata, indent)
+	}
 
 	var details string
 	if metadata.DetailedDiff != nil {
 		var buf bytes.Buffer
 		if diff := translateDetailedDiff(metadata); diff != nil {
-			engine.PrintObjectDiff(&buf, *diff, nil /*include*/, planning, indent+1, opts.SummaryDiff, debug)
-		} else {
+			if opts.ChangesOnly {
+				engine.PrintObjectDiffChanges(&buf, *diff, nil /*include*/, planning, indent+1, debug)
+			} else {
+				engine.PrintObjectDiff(&buf, *diff, nil /*include*/, planning, indent+1, opts.SummaryDiff, debug)
+			}
+		} else if !opts.ChangesOnly {
 			engine.PrintObject(
 				&buf, metadata.Old.Inputs, planning, indent+1, deploy.OpSame, true /*prefix*/, debug)
 		}
 		details = buf.String()
+	} else if opts.ChangesOnly {
+		details = engine.GetResourcePropertiesChanges(metadata, indent, planning, debug)
 	} else {
 		details = engine.GetResourcePropertiesDetails(
 			metadata, indent, planning, opts.SummaryDiff, debug)
@@ -339,7 +414,7 @@ func renderDiffResourcePreEvent(
 	}
 
 	out := &bytes.Buffer{}
-	if shouldShow(payload.Metadata, opts) || isRootStack(payload.Metadata) {
+	if shouldShowFiltered(payload.Metadata, opts) || isRootStack(payload.Metadata) {
 		renderDiff(out, payload.Metadata, payload.Planning, payload.Debug, seen, opts)
 	}
 	return out.String()
@@ -351,7 +426,7 @@ func renderDiffResourceOutputsEvent(
 	opts Options) string {
 
 	out := &bytes.Buffer{}
-	if shouldShow(payload.Metadata, opts) || isRootStack(payload.Metadata) {
+	if shouldShowFiltered(payload.Metadata, opts) || isRootStack(payload.Metadata) {
 		// If this is the output step for an import, we actually want to display the diff at this point.
 		if payload.Metadata.Op == deploy.OpImport {
 			renderDiff(out, payload.Metadata, payload.Planning, payload.Debug, seen, opts)
@@ -371,7 +446,7 @@ func renderDiffResourceOutputsEvent(
 			// We want to hide same outputs if we're doing a read and the user didn't ask to see
 			// things that are the same.
 			text := engine.GetResourceOutputsPropertiesString(
-				payload.Metadata, indent+1, payload.Planning,
+				truncateStepProperties(payload.Metadata, opts.MaxValueLength), indent+1, payload.Planning,
 				payload.Debug, refresh, opts.ShowSameResources)
 			if text != "" {
 				header := fmt.Sprintf("%v%v--outputs:--%v\n",
@@ -16,9 +16,12 @@ package display
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/v2/engine"
@@ -40,6 +43,9 @@ func ShowEvents(
 	if opts.EventLogPath != "" {
 		events, done = startEventLogger(events, done, opts.EventLogPath)
 	}
+	if opts.DotGraphPath != "" {
+		events, done = startDotWriter(events, done, opts.DotGraphPath, opts.DotGraphClusters)
+	}
 
 	if opts.JSONDisplay {
 		// TODO[pulumi/pulumi#2390]: enable JSON display for real deployments.
@@ -147,6 +153,87 @@ func shouldShow(step engine.StepEventMetadata, opts Options) bool {
 	return true
 }
 
+// shouldShowFiltered returns true if a step should show in the output of a renderer that honors the display filters.
+// Only the progress and diff displays are filtered: the JSON display is meant to be consumed by tools, so it always
+// includes every step.
+func shouldShowFiltered(step engine.StepEventMetadata, opts Options) bool {
+	// If the display has been filtered, only show the resources that match. This has no effect on what is deployed.
+	return matchesFilters(step.URN, opts.Filters) && shouldShow(step, opts)
+}
+
+// matchesFilters returns true if the given resource matches any of the given filters, or if there are no filters. Each
+// filter is a glob pattern (see compileFilter) that is matched against both the resource's type and its name, so e.g.
+// `aws:ec2/*` matches all EC2 resources, `aws:*` matches all AWS resources and `web-*` matches all resources whose
+// names start with `web-`.
+func matchesFilters(urn resource.URN, filters []*regexp.Regexp) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, re := range filters {
+		if re.MatchString(string(urn.Type())) || re.MatchString(string(urn.Name())) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileFilter compiles a display filter into a regular expression that matches an entire string. Filters use the
+// syntax of path.Match, except that `*` matches any sequence of characters including `/`: resource types contain a
+// `/` between their module and name, so a `*` that stopped at `/` would make `aws:*` match nothing useful.
+func compileFilter(filter string) (*regexp.Regexp, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for runes := []rune(filter); len(runes) > 0; runes = runes[1:] {
+		switch c := runes[0]; c {
+		case '*':
+			pattern.WriteString(".*")
+		case '?':
+			pattern.WriteString(".")
+		case '[':
+			end := -1
+			for i := 1; i < len(runes); i++ {
+				if runes[i] == ']' {
+					end = i
+					break
+				}
+			}
+			if end < 0 {
+				return nil, errors.New("unterminated character class")
+			}
+			class := string(runes[1:end])
+			if class == "" || class == "^" {
+				return nil, errors.New("empty character class")
+			}
+			pattern.WriteString("[" + class + "]")
+			runes = runes[end:]
+		case '\\':
+			if len(runes) == 1 {
+				return nil, errors.New("trailing escape character")
+			}
+			runes = runes[1:]
+			pattern.WriteString(regexp.QuoteMeta(string(runes[0])))
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	pattern.WriteString("$")
+	return regexp.Compile(pattern.String())
+}
+
+// CompileFilters compiles the given display filters for use in Options.Filters. It returns an error if any of the
+// filters is not a valid glob pattern.
+func CompileFilters(filters []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, filter := range filters {
+		re, err := compileFilter(filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter '%s': %v", filter, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
 func fprintfIgnoreError(w io.Writer, format string, a ...interface{}) {
 	_, err := fmt.Fprintf(w, format, a...)
 	contract.IgnoreError(err)
@@ -0,0 +1,62 @@
+package display
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestMatchesFilters(t *testing.T) {
+	instance := resource.URN("urn:pulumi:stack::proj::aws:ec2/instance:Instance::web-server")
+	bucket := resource.URN("urn:pulumi:stack::proj::aws:s3/bucket:Bucket::assets")
+
+	assert.True(t, matchesFilters(instance, nil))
+	assert.True(t, matchesFilters(bucket, nil))
+
+	assert.True(t, matchesFilters(instance, mustCompileFilters(t, []string{"aws:ec2/*"})))
+	assert.False(t, matchesFilters(bucket, mustCompileFilters(t, []string{"aws:ec2/*"})))
+
+	// `*` matches across the `/` between a type's module and name.
+	assert.True(t, matchesFilters(instance, mustCompileFilters(t, []string{"aws:*"})))
+	assert.True(t, matchesFilters(bucket, mustCompileFilters(t, []string{"aws:*"})))
+	assert.False(t, matchesFilters(bucket, mustCompileFilters(t, []string{"gcp:*"})))
+
+	assert.True(t, matchesFilters(instance, mustCompileFilters(t, []string{"web-*"})))
+	assert.False(t, matchesFilters(bucket, mustCompileFilters(t, []string{"web-*"})))
+
+	assert.True(t, matchesFilters(bucket, mustCompileFilters(t, []string{"web-*", "assets"})))
+}
+
+func TestFiltersOnlyApplyToFilteredDisplays(t *testing.T) {
+	step := engine.StepEventMetadata{
+		Op:      deploy.OpCreate,
+		URN:     resource.URN("urn:pulumi:stack::proj::aws:s3/bucket:Bucket::assets"),
+		Logical: true,
+	}
+	opts := Options{Filters: mustCompileFilters(t, []string{"aws:ec2/*"})}
+
+	assert.False(t, shouldShowFiltered(step, opts))
+	assert.True(t, shouldShow(step, opts))
+}
+
+func TestCompileFilters(t *testing.T) {
+	compiled, err := CompileFilters([]string{"aws:ec2/*", "web-?"})
+	assert.NoError(t, err)
+	assert.Len(t, compiled, 2)
+
+	_, err = CompileFilters([]string{"aws:ec2/[*"})
+	assert.Error(t, err)
+}
+
+func mustCompileFilters(t *testing.T, filters []string) []*regexp.Regexp {
+	compiled, err := CompileFilters(filters)
+	if err != nil {
+		t.Fatalf("compiling filters: %v", err)
+	}
+	return compiled
+}
@@ -0,0 +1,230 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/graph"
+	"github.com/pulumi/pulumi/pkg/v2/graph/dotconv"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+// startDotWriter records the steps of an update as its events pass through to the display, and once all events have
+// been displayed, writes a DOT graph of those steps to the file at the given path. If clustered is true, the graph's
+// resources are grouped by package.
+func startDotWriter(events <-chan engine.Event, done chan<- bool, path string,
+	clustered bool) (<-chan engine.Event, chan<- bool) {
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		g := newStepGraph()
+		for e := range events {
+			if e.Type == engine.ResourcePreEvent {
+				g.record(e.Payload().(engine.ResourcePreEventPayload).Metadata)
+			}
+
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+
+		if err := writeStepGraph(g, path, clustered); err != nil {
+			cmdutil.Diag().Warningf(diag.Message("", "could not write DOT graph to %s: %v"), path, err)
+		}
+	}()
+
+	return outEvents, outDone
+}
+
+// writeStepGraph writes a DOT graph of the given steps to the file at the given path. The graph is rendered in memory
+// first, so the file is only written once the whole graph is available.
+func writeStepGraph(g *stepGraph, path string, clustered bool) error {
+	g.connect()
+
+	var buf bytes.Buffer
+	var err error
+	if clustered {
+		err = dotconv.PrintClustered(g, &buf, stepPackage)
+	} else {
+		err = dotconv.Print(g, &buf)
+	}
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// stepPackage returns the package of the resource that a step graph vertex represents, e.g. "aws" for an S3 bucket.
+func stepPackage(v graph.Vertex) string {
+	if pkg := v.(*stepVertex).step.URN.Type().Package(); pkg != "" {
+		return string(pkg)
+	}
+	return "default"
+}
+
+// stepGraph implements graph.Graph for the steps of an update. Each resource is a vertex, colored by the operation
+// performed on it, and each dependency between resources is an edge from the dependency to the dependent.
+type stepGraph struct {
+	vertices []*stepVertex
+	byURN    map[resource.URN]*stepVertex
+}
+
+func newStepGraph() *stepGraph {
+	return &stepGraph{byURN: make(map[resource.URN]*stepVertex)}
+}
+
+// record adds the given step to the graph. A resource may be the subject of several steps (e.g. the physical steps of a
+// replacement); its logical step is the one that is shown.
+func (g *stepGraph) record(step engine.StepEventMetadata) {
+	if step.URN == "" {
+		return
+	}
+	if v, has := g.byURN[step.URN]; has {
+		if step.Logical {
+			v.step = step
+		}
+		return
+	}
+
+	v := &stepVertex{step: step}
+	g.vertices = append(g.vertices, v)
+	g.byURN[step.URN] = v
+}
+
+// connect adds an edge for each dependency between the recorded resources. It must be called once all of the
+// update's steps have been recorded.
+func (g *stepGraph) connect() {
+	for _, v := range g.vertices {
+		for _, dep := range v.dependencies() {
+			if d, has := g.byURN[dep]; has {
+				e := &stepEdge{from: d, to: v}
+				v.ins = append(v.ins, e)
+				d.outs = append(d.outs, e)
+			}
+		}
+	}
+}
+
+// Roots returns edges to every vertex in the graph, in the order in which their steps were recorded.
+func (g *stepGraph) Roots() []graph.Edge {
+	roots := make([]graph.Edge, len(g.vertices))
+	for i, v := range g.vertices {
+		roots[i] = &stepEdge{to: v}
+	}
+	return roots
+}
+
+type stepVertex struct {
+	step engine.StepEventMetadata
+	ins  []graph.Edge
+	outs []graph.Edge
+}
+
+func (v *stepVertex) dependencies() []resource.URN {
+	state := v.step.New
+	if state == nil {
+		state = v.step.Old
+	}
+	if state == nil || state.State == nil {
+		return nil
+	}
+	return state.State.Dependencies
+}
+
+func (v *stepVertex) Data() interface{} {
+	return v.step
+}
+
+func (v *stepVertex) Label() string {
+	return string(v.step.URN)
+}
+
+func (v *stepVertex) Ins() []graph.Edge {
+	return v.ins
+}
+
+func (v *stepVertex) Outs() []graph.Edge {
+	return v.outs
+}
+
+// Color returns the DOT equivalent of the color used for the vertex's operation in the rest of the display.
+func (v *stepVertex) Color() string {
+	return dotColor(v.step.Op.Color())
+}
+
+// dotColor returns the name of the DOT color that corresponds to the given colorization commands, if any.
+func dotColor(c string) string {
+	for _, color := range []struct {
+		command string
+		name    string
+	}{
+		{colors.Red, "red"},
+		{colors.BrightRed, "red"},
+		{colors.Green, "green"},
+		{colors.BrightGreen, "green"},
+		{colors.Yellow, "gold"},
+		{colors.Blue, "blue"},
+		{colors.BrightBlue, "blue"},
+		{colors.Magenta, "magenta"},
+		{colors.BrightMagenta, "magenta"},
+		{colors.Cyan, "cyan"},
+		{colors.BrightCyan, "cyan"},
+	} {
+		if strings.Contains(c, color.command) {
+			return color.name
+		}
+	}
+	return ""
+}
+
+type stepEdge struct {
+	from *stepVertex
+	to   *stepVertex
+}
+
+// In this simple case, edges have no data.
+func (e *stepEdge) Data() interface{} {
+	return nil
+}
+
+// In this simple case, edges have no label.
+func (e *stepEdge) Label() string {
+	return ""
+}
+
+func (e *stepEdge) To() graph.Vertex {
+	return e.to
+}
+
+func (e *stepEdge) From() graph.Vertex {
+	return e.from
+}
+
+func (e *stepEdge) Color() string {
+	return ""
+}
@@ -0,0 +1,99 @@
+package display
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/graph/dotconv"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestStepGraph(t *testing.T) {
+	const a, b, c = "urn:pulumi:stack::proj::pkg:index:typ::a", "urn:pulumi:stack::proj::pkg:index:typ::b",
+		"urn:pulumi:stack::proj::pkg:index:typ::c"
+
+	step := func(urn resource.URN, op deploy.StepOp, logical bool, deps ...resource.URN) engine.StepEventMetadata {
+		return engine.StepEventMetadata{
+			URN:     urn,
+			Op:      op,
+			Logical: logical,
+			New:     &engine.StepEventStateMetadata{State: &resource.State{URN: urn, Dependencies: deps}},
+		}
+	}
+
+	g := newStepGraph()
+	g.record(step(a, deploy.OpCreate, true))
+	g.record(step(b, deploy.OpCreateReplacement, false, a))
+	g.record(step(b, deploy.OpReplace, true, a))
+	g.record(step(b, deploy.OpDeleteReplaced, false, a))
+	g.record(step(c, deploy.OpSame, true, b))
+	g.connect()
+
+	var buf bytes.Buffer
+	assert.NoError(t, dotconv.Print(g, &buf))
+	assert.Equal(t, "strict digraph {\n"+
+		"    Resource0 [label=\""+a+"\", color=\"green\"];\n"+
+		"    Resource0 -> Resource1;\n"+
+		"    Resource1 [label=\""+b+"\", color=\"magenta\"];\n"+
+		"    Resource1 -> Resource2;\n"+
+		"    Resource2 [label=\""+c+"\"];\n"+
+		"}\n", buf.String())
+}
+
+func TestStepGraphClustered(t *testing.T) {
+	const a, b = "urn:pulumi:stack::proj::aws:s3/bucket:Bucket::a", "urn:pulumi:stack::proj::random:index:Id::b"
+
+	g := newStepGraph()
+	g.record(engine.StepEventMetadata{URN: a, Op: deploy.OpCreate, Logical: true})
+	g.record(engine.StepEventMetadata{
+		URN:     b,
+		Op:      deploy.OpCreate,
+		Logical: true,
+		New:     &engine.StepEventStateMetadata{State: &resource.State{URN: b, Dependencies: []resource.URN{a}}},
+	})
+	g.connect()
+
+	var buf bytes.Buffer
+	assert.NoError(t, dotconv.PrintClustered(g, &buf, stepPackage))
+	assert.Equal(t, "strict digraph {\n"+
+		"    Resource0 -> Resource1;\n"+
+		"    subgraph cluster_0 {\n"+
+		"        label=\"aws\";\n"+
+		"        Resource0 [label=\""+a+"\", color=\"green\"];\n"+
+		"    }\n"+
+		"    subgraph cluster_1 {\n"+
+		"        label=\"random\";\n"+
+		"        Resource1 [label=\""+b+"\", color=\"green\"];\n"+
+		"    }\n"+
+		"}\n", buf.String())
+}
+
+func TestWriteStepGraph(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dot")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	g := newStepGraph()
+	g.record(engine.StepEventMetadata{URN: "urn:pulumi:stack::proj::pkg:index:typ::a", Op: deploy.OpCreate, Logical: true})
+
+	path := filepath.Join(dir, "update.dot")
+	assert.NoError(t, writeStepGraph(g, path, false))
+	contents, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(contents), "strict digraph {")
+
+	// Failing to write the graph is reported rather than leaving a partial file behind.
+	path = filepath.Join(dir, "missing", "update.dot")
+	assert.Error(t, writeStepGraph(g, path, false))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
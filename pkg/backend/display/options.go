@@ -14,7 +14,11 @@
 
 package display
 
-import "github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+import (
+	"regexp"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+)
 
 // Type of output to display.
 type Type int
@@ -37,11 +41,18 @@ type Options struct {
 	ShowReplacementSteps bool                // true to show the replacement steps in the plan.
 	ShowSameResources    bool                // true to show the resources that aren't updated in addition to updates.
 	ShowReads            bool                // true to show resources that are being read in
+	ShowDependencies     bool                // true to show the resources that each resource depends on.
 	SuppressOutputs      bool                // true to suppress output summarization, e.g. if contains sensitive info.
 	SummaryDiff          bool                // true if diff display should be summarized.
+	ChangesOnly          bool                // true if diff display should only show the paths of changed properties.
+	ShowStats            bool                // true to show dependency graph statistics after the summary.
+	Filters              []*regexp.Regexp    // if non-empty, only show resources whose type or name match a filter (see CompileFilters).
+	MaxValueLength       int                 // if positive, the number of characters of long strings to display.
 	IsInteractive        bool                // true if we should display things interactively.
 	Type                 Type                // type of display (rich diff, progress, or query).
 	JSONDisplay          bool                // true if we should emit the entire diff as JSON.
 	EventLogPath         string              // the path to the file to use for logging events, if any.
+	DotGraphPath         string              // the path to the file to which to write a DOT graph of the steps, if any.
+	DotGraphClusters     bool                // true to group the resources in the DOT graph by package.
 	Debug                bool                // true to enable debug output.
 }
@@ -115,6 +115,12 @@ type ProgressDisplay struct {
 	// messages we're outputting for them.
 	summaryEventPayload *engine.SummaryEventPayload
 
+	// The states that the update's steps leave behind, used to render graph statistics if requested.
+	statsStates map[resource.URN]*resource.State
+
+	// How long each of the update's steps took. Only populated when we are not previewing.
+	timings *stepTimings
+
 	// Any system events we've received.  They will be printed at the bottom of all the status rows
 	systemEventPayloads []engine.StdoutEventPayload
 
@@ -263,6 +269,8 @@ func ShowProgressEvents(op string, action apitype.UpdateKind, stack tokens.QName
 		proj:                   proj,
 		progressOutput:         progressOutput,
 		eventUrnToResourceRow:  make(map[resource.URN]ResourceRow),
+		statsStates:            make(map[resource.URN]*resource.State),
+		timings:                newStepTimings(),
 		suffixColumn:           int(statusColumn),
 		suffixesArray:          []string{"", ".", "..", "..."},
 		urnToID:                make(map[resource.URN]string),
@@ -842,6 +850,7 @@ func (display *ProgressDisplay) printOutputs() {
 	}
 
 	stackStep := display.eventUrnToResourceRow[display.stackUrn].Step()
+	stackStep = truncateStepProperties(stackStep, display.opts.MaxValueLength)
 
 	props := engine.GetResourceOutputsPropertiesString(
 		stackStep, 1, display.isPreview, display.opts.Debug,
@@ -861,6 +870,19 @@ func (display *ProgressDisplay) printSummary(wroteDiagnosticHeader bool) {
 
 	msg := renderSummaryEvent(display.action, *display.summaryEventPayload, wroteDiagnosticHeader, display.opts)
 	display.writeSimpleMessage(msg)
+
+	if display.opts.ShowStats {
+		display.writeSimpleMessage(renderGraphStats(display.statsStates, display.opts))
+		if diffStats := renderDiffStats(display.summaryEventPayload.DiffStats, display.opts); diffStats != "" {
+			display.writeSimpleMessage(diffStats)
+		}
+	}
+
+	if !display.isPreview {
+		if timings := renderStepTimings(display.timings, display.opts); timings != "" {
+			display.writeSimpleMessage(timings)
+		}
+	}
 }
 
 func (display *ProgressDisplay) mergeStreamPayloadsToSinglePayload(
@@ -1032,7 +1054,7 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	// Don't bother showing certain events (for example, things that are unchanged). However
 	// always show the root 'stack' resource so we can indicate that it's still running, and
 	// also so we have something to attach unparented diagnostic events to.
-	hideRowIfUnnecessary := metadata != nil && !shouldShow(*metadata, display.opts) && !isRootEvent
+	hideRowIfUnnecessary := metadata != nil && !shouldShowFiltered(*metadata, display.opts) && !isRootEvent
 	// Always show row if there's a policy violation event. Policy violations prevent resource
 	// registration, so if we don't show the row, the violation gets attributed to the stack
 	// resource rather than the resources whose policy failed.
@@ -1044,6 +1066,12 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	if event.Type == engine.ResourcePreEvent {
 		step := event.Payload().(engine.ResourcePreEventPayload).Metadata
 		row.SetStep(step)
+		if display.opts.ShowStats {
+			recordGraphStatsState(display.statsStates, step)
+		}
+		if !display.isPreview {
+			display.timings.start(step)
+		}
 	} else if event.Type == engine.ResourceOutputsEvent {
 		isRefresh := display.getStepOp(row.Step()) == deploy.OpRefresh
 		step := event.Payload().(engine.ResourceOutputsEventPayload).Metadata
@@ -1053,6 +1081,10 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 			display.seenStackOutputs = true
 		}
 
+		if !display.isPreview {
+			display.timings.end(step)
+		}
+
 		row.SetStep(step)
 		row.AddOutputStep(step)
 
@@ -1064,6 +1096,9 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 			return
 		}
 	} else if event.Type == engine.ResourceOperationFailed {
+		if !display.isPreview {
+			display.timings.end(event.Payload().(engine.ResourceOperationFailedPayload).Metadata)
+		}
 		row.SetFailed()
 	} else if event.Type == engine.DiagEvent {
 		// also record this diagnostic so we print it at the end.
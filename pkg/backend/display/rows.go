@@ -304,6 +304,9 @@ func (data *resourceRowData) ColorizedColumns() []string {
 	if data.IsDone() {
 		failed := data.failed || diagInfo.ErrorCount > 0
 		columns[statusColumn] = data.display.getStepDoneDescription(step, failed)
+		if d, has := data.display.timings.duration(step); has {
+			columns[statusColumn] += " (" + formatStepDuration(d) + ")"
+		}
 	} else {
 		columns[statusColumn] = data.display.getStepInProgressDescription(step)
 	}
@@ -0,0 +1,110 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// stepTimingKey identifies a single step applied to a resource. A resource may be the subject of several steps (e.g.
+// the create and delete halves of a replacement), each of which is timed separately.
+type stepTimingKey struct {
+	urn resource.URN
+	op  deploy.StepOp
+}
+
+// stepTimings records how long each step of an update took, as observed by the display.
+type stepTimings struct {
+	now       func() time.Time
+	starts    map[stepTimingKey]time.Time
+	durations map[stepTimingKey]time.Duration
+	opTotals  map[deploy.StepOp]time.Duration
+	opCounts  map[deploy.StepOp]int
+}
+
+func newStepTimings() *stepTimings {
+	return &stepTimings{
+		now:       time.Now,
+		starts:    make(map[stepTimingKey]time.Time),
+		durations: make(map[stepTimingKey]time.Duration),
+		opTotals:  make(map[deploy.StepOp]time.Duration),
+		opCounts:  make(map[deploy.StepOp]int),
+	}
+}
+
+// start records the start of the given step. Steps that do no work are not timed.
+func (t *stepTimings) start(step engine.StepEventMetadata) {
+	if step.Op == deploy.OpSame {
+		return
+	}
+	key := stepTimingKey{urn: step.URN, op: step.Op}
+	if _, has := t.starts[key]; !has {
+		t.starts[key] = t.now()
+	}
+}
+
+// end records the completion, successful or otherwise, of the given step.
+func (t *stepTimings) end(step engine.StepEventMetadata) {
+	key := stepTimingKey{urn: step.URN, op: step.Op}
+	start, has := t.starts[key]
+	if !has {
+		return
+	}
+	if _, done := t.durations[key]; done {
+		return
+	}
+
+	d := t.now().Sub(start)
+	t.durations[key] = d
+	t.opTotals[step.Op] += d
+	t.opCounts[step.Op]++
+}
+
+// duration returns how long the given step took, if it has completed.
+func (t *stepTimings) duration(step engine.StepEventMetadata) (time.Duration, bool) {
+	d, has := t.durations[stepTimingKey{urn: step.URN, op: step.Op}]
+	return d, has
+}
+
+// formatStepDuration formats a step's duration to a tenth of a second, which is precise enough to tell fast steps from
+// slow ones without being noisy.
+func formatStepDuration(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// renderStepTimings renders the total time spent on each kind of step.
+func renderStepTimings(t *stepTimings, opts Options) string {
+	if len(t.opCounts) == 0 {
+		return ""
+	}
+
+	out := &bytes.Buffer{}
+	fprintIgnoreError(out, opts.Color.Colorize(
+		fmt.Sprintf("\n%sTime by operation:%s\n", colors.SpecHeadline, colors.Reset)))
+	for _, op := range deploy.StepOps {
+		if c := t.opCounts[op]; c > 0 {
+			fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("    %s%d %s in %s%s\n",
+				op.Prefix(), c, op.PastTense(), formatStepDuration(t.opTotals[op]), colors.Reset)))
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,56 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestStepTimings(t *testing.T) {
+	now := time.Unix(0, 0)
+	timings := newStepTimings()
+	timings.now = func() time.Time { return now }
+
+	a := engine.StepEventMetadata{URN: resource.URN("urn:pulumi:stack::proj::pkg:index:typ::a"), Op: deploy.OpCreate}
+	b := engine.StepEventMetadata{URN: resource.URN("urn:pulumi:stack::proj::pkg:index:typ::b"), Op: deploy.OpCreate}
+	c := engine.StepEventMetadata{URN: resource.URN("urn:pulumi:stack::proj::pkg:index:typ::c"), Op: deploy.OpUpdate}
+	same := engine.StepEventMetadata{URN: resource.URN("urn:pulumi:stack::proj::pkg:index:typ::d"), Op: deploy.OpSame}
+
+	timings.start(a)
+	timings.start(b)
+	timings.start(c)
+	timings.start(same)
+
+	now = now.Add(1200 * time.Millisecond)
+	timings.end(a)
+	timings.end(c)
+	now = now.Add(2 * time.Second)
+	timings.end(b)
+	timings.end(same)
+
+	// Ending a step twice must not count it twice.
+	timings.end(a)
+
+	d, has := timings.duration(a)
+	assert.True(t, has)
+	assert.Equal(t, "1.2s", formatStepDuration(d))
+	d, has = timings.duration(b)
+	assert.True(t, has)
+	assert.Equal(t, "3.2s", formatStepDuration(d))
+	_, has = timings.duration(same)
+	assert.False(t, has)
+
+	assert.Equal(t,
+		"\nTime by operation:\n    + 2 created in 4.4s\n    ~ 1 updated in 1.2s\n",
+		renderStepTimings(timings, Options{Color: colors.Never}))
+}
+
+func TestStepTimingsEmpty(t *testing.T) {
+	assert.Equal(t, "", renderStepTimings(newStepTimings(), Options{Color: colors.Never}))
+}
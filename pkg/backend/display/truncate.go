@@ -0,0 +1,206 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// DefaultMaxValueLength is the default number of characters of a string property value that are displayed before the
+// rest of the value is elided.
+const DefaultMaxValueLength = 200
+
+// truncateStepProperties returns a copy of the given step whose long string property values have been truncated to at
+// most maxLength characters. If the step has both old and new states, their values are truncated together: when a long
+// string has changed, both sides show the region around the first change rather than the (identical) start of the
+// string, so the change is still visible in a diff. Changed strings that also differ outside of that region are not
+// truncated at all. A maxLength of zero or less disables truncation.
+func truncateStepProperties(step engine.StepEventMetadata, maxLength int) engine.StepEventMetadata {
+	if maxLength <= 0 {
+		return step
+	}
+
+	var oldInputs, oldOutputs, newInputs, newOutputs resource.PropertyMap
+	if step.Old != nil {
+		oldInputs, oldOutputs = step.Old.Inputs, step.Old.Outputs
+	}
+	if step.New != nil {
+		newInputs, newOutputs = step.New.Inputs, step.New.Outputs
+	}
+	oldInputs, newInputs = truncatePropertyMaps(oldInputs, newInputs, maxLength)
+	oldOutputs, newOutputs = truncatePropertyMaps(oldOutputs, newOutputs, maxLength)
+
+	if step.Old != nil {
+		old := *step.Old
+		old.Inputs, old.Outputs = oldInputs, oldOutputs
+		step.Old = &old
+	}
+	if step.New != nil {
+		new := *step.New
+		new.Inputs, new.Outputs = newInputs, newOutputs
+		step.New = &new
+	}
+	return step
+}
+
+// truncatePropertyMaps truncates the values of a pair of old and new property maps. Either map may be nil.
+func truncatePropertyMaps(olds, news resource.PropertyMap, maxLength int) (resource.PropertyMap, resource.PropertyMap) {
+	var truncatedOlds, truncatedNews resource.PropertyMap
+	if olds != nil {
+		truncatedOlds = make(resource.PropertyMap, len(olds))
+	}
+	if news != nil {
+		truncatedNews = make(resource.PropertyMap, len(news))
+	}
+
+	for k, old := range olds {
+		if new, has := news[k]; has {
+			truncatedOlds[k], truncatedNews[k] = truncatePropertyValues(old, new, maxLength)
+		} else {
+			truncatedOlds[k] = truncatePropertyValue(old, maxLength)
+		}
+	}
+	for k, new := range news {
+		if _, has := olds[k]; !has {
+			truncatedNews[k] = truncatePropertyValue(new, maxLength)
+		}
+	}
+	return truncatedOlds, truncatedNews
+}
+
+// truncatePropertyValues truncates a pair of old and new property values.
+func truncatePropertyValues(old, new resource.PropertyValue,
+	maxLength int) (resource.PropertyValue, resource.PropertyValue) {
+
+	switch {
+	case old.IsString() && new.IsString():
+		oldRunes, newRunes := []rune(old.StringValue()), []rune(new.StringValue())
+
+		// If the strings differ, center the displayed region a little before the first difference so that the change
+		// is shown with some context.
+		start := 0
+		if prefix := commonPrefixLength(oldRunes, newRunes); prefix < len(oldRunes) || prefix < len(newRunes) {
+			if start = prefix - maxLength/4; start < 0 {
+				start = 0
+			}
+
+			// Only the region around the first difference is displayed, so if the strings also differ after that
+			// region, truncating them would hide a change. Show them in full instead.
+			suffix := commonSuffixLength(oldRunes[prefix:], newRunes[prefix:])
+			if len(oldRunes)-suffix > truncatedEnd(oldRunes, start, maxLength) ||
+				len(newRunes)-suffix > truncatedEnd(newRunes, start, maxLength) {
+				return old, new
+			}
+		}
+		return resource.NewStringProperty(truncateRunes(oldRunes, start, maxLength)),
+			resource.NewStringProperty(truncateRunes(newRunes, start, maxLength))
+	case old.IsArray() && new.IsArray():
+		oldArr, newArr := old.ArrayValue(), new.ArrayValue()
+		truncatedOld := make([]resource.PropertyValue, len(oldArr))
+		truncatedNew := make([]resource.PropertyValue, len(newArr))
+		for i := range oldArr {
+			if i < len(newArr) {
+				truncatedOld[i], truncatedNew[i] = truncatePropertyValues(oldArr[i], newArr[i], maxLength)
+			} else {
+				truncatedOld[i] = truncatePropertyValue(oldArr[i], maxLength)
+			}
+		}
+		for i := len(oldArr); i < len(newArr); i++ {
+			truncatedNew[i] = truncatePropertyValue(newArr[i], maxLength)
+		}
+		return resource.NewArrayProperty(truncatedOld), resource.NewArrayProperty(truncatedNew)
+	case old.IsObject() && new.IsObject():
+		truncatedOld, truncatedNew := truncatePropertyMaps(old.ObjectValue(), new.ObjectValue(), maxLength)
+		return resource.NewObjectProperty(truncatedOld), resource.NewObjectProperty(truncatedNew)
+	default:
+		return truncatePropertyValue(old, maxLength), truncatePropertyValue(new, maxLength)
+	}
+}
+
+// truncatePropertyValue truncates a single property value that has nothing to be compared with.
+func truncatePropertyValue(v resource.PropertyValue, maxLength int) resource.PropertyValue {
+	switch {
+	case v.IsString():
+		return resource.NewStringProperty(truncateRunes([]rune(v.StringValue()), 0, maxLength))
+	case v.IsArray():
+		arr := v.ArrayValue()
+		truncated := make([]resource.PropertyValue, len(arr))
+		for i, elem := range arr {
+			truncated[i] = truncatePropertyValue(elem, maxLength)
+		}
+		return resource.NewArrayProperty(truncated)
+	case v.IsObject():
+		truncated, _ := truncatePropertyMaps(v.ObjectValue(), nil, maxLength)
+		return resource.NewObjectProperty(truncated)
+	default:
+		return v
+	}
+}
+
+// truncateRunes returns the maxLength characters of s starting at start, marking any elided characters with an
+// ellipsis and noting how many were left out. Strings of at most maxLength characters are returned unchanged.
+func truncateRunes(s []rune, start, maxLength int) string {
+	if len(s) <= maxLength {
+		return string(s)
+	}
+	if start > len(s)-maxLength {
+		start = len(s) - maxLength
+	}
+	end := start + maxLength
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	b.WriteString(string(s[start:end]))
+	if end < len(s) {
+		b.WriteString("…")
+	}
+	fmt.Fprintf(&b, " (%d more chars)", len(s)-maxLength)
+	return b.String()
+}
+
+// truncatedEnd returns the index just past the last character of s that truncateRunes displays for the given start.
+func truncatedEnd(s []rune, start, maxLength int) int {
+	if len(s) <= maxLength {
+		return len(s)
+	}
+	if start > len(s)-maxLength {
+		start = len(s) - maxLength
+	}
+	return start + maxLength
+}
+
+// commonPrefixLength returns the number of leading characters that a and b have in common.
+func commonPrefixLength(a, b []rune) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// commonSuffixLength returns the number of trailing characters that a and b have in common.
+func commonSuffixLength(a, b []rune) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[len(a)-1-i] == b[len(b)-1-i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,80 @@
+package display
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestTruncateRunes(t *testing.T) {
+	assert.Equal(t, "abcdef", truncateRunes([]rune("abcdef"), 0, 6))
+	assert.Equal(t, "abcd… (2 more chars)", truncateRunes([]rune("abcdef"), 0, 4))
+	assert.Equal(t, "…bcde… (2 more chars)", truncateRunes([]rune("abcdef"), 1, 4))
+	assert.Equal(t, "…cdef (2 more chars)", truncateRunes([]rune("abcdef"), 5, 4))
+	assert.Equal(t, "héll… (2 more chars)", truncateRunes([]rune("héllo!"), 0, 4))
+}
+
+func TestTruncateStepProperties(t *testing.T) {
+	long := strings.Repeat("a", 20)
+	changedOld, changedNew := long+"old"+long, long+"new"+long
+
+	step := engine.StepEventMetadata{
+		Old: &engine.StepEventStateMetadata{
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+				"same":    long,
+				"changed": changedOld,
+				"nested":  []interface{}{map[string]interface{}{"value": long}},
+				"short":   "short",
+			}),
+		},
+		New: &engine.StepEventStateMetadata{
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+				"same":    long,
+				"changed": changedNew,
+				"nested":  []interface{}{map[string]interface{}{"value": long}},
+				"short":   "short",
+				"added":   long,
+			}),
+		},
+	}
+
+	truncated := truncateStepProperties(step, 8)
+	olds, news := truncated.Old.Inputs, truncated.New.Inputs
+
+	assert.Equal(t, "aaaaaaaa… (12 more chars)", olds["same"].StringValue())
+	assert.Equal(t, olds["same"], news["same"])
+	assert.Equal(t, "…aaoldaaa… (35 more chars)", olds["changed"].StringValue())
+	assert.Equal(t, "…aanewaaa… (35 more chars)", news["changed"].StringValue())
+	assert.Equal(t, "aaaaaaaa… (12 more chars)",
+		news["nested"].ArrayValue()[0].ObjectValue()["value"].StringValue())
+	assert.Equal(t, "short", news["short"].StringValue())
+	assert.Equal(t, "aaaaaaaa… (12 more chars)", news["added"].StringValue())
+
+	// The original step must not be modified.
+	assert.Equal(t, changedOld, step.Old.Inputs["changed"].StringValue())
+
+	// A non-positive length disables truncation.
+	assert.Equal(t, step, truncateStepProperties(step, 0))
+}
+
+func TestTruncateStringWithSeparateChanges(t *testing.T) {
+	long := strings.Repeat("a", 20)
+	old := resource.NewStringProperty(long + "old" + long + "old" + long)
+	new := resource.NewStringProperty(long + "new" + long + "new" + long)
+
+	// The second change is outside of the region around the first, so neither string is truncated.
+	truncatedOld, truncatedNew := truncatePropertyValues(old, new, 8)
+	assert.Equal(t, old, truncatedOld)
+	assert.Equal(t, new, truncatedNew)
+
+	// Changes that fit in the displayed region are still truncated around the first one.
+	old = resource.NewStringProperty(long + "old" + long)
+	new = resource.NewStringProperty(long + "new" + long)
+	truncatedOld, truncatedNew = truncatePropertyValues(old, new, 8)
+	assert.Equal(t, "…aaoldaaa… (35 more chars)", truncatedOld.StringValue())
+	assert.Equal(t, "…aanewaaa… (35 more chars)", truncatedNew.StringValue())
+}
@@ -60,7 +60,10 @@ import (
 // Backend extends the base backend interface with specific information about local backends.
 type Backend interface {
 	backend.Backend
-	local() // at the moment, no local specific info, so just use a marker function.
+	local() // a marker function that identifies local backends.
+
+	// ForceUnlock removes the lock held by the stack's current update, e.g. one left behind by a crash.
+	ForceUnlock(ctx context.Context, stackRef backend.StackReference) error
 }
 
 type localBackend struct {
@@ -287,7 +290,7 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 		return nil, errors.Wrap(err, "validating stack properties")
 	}
 
-	file, err := b.saveStack(stackName, nil, nil)
+	file, err := b.saveStack(stackName, nil, nil, true /* backup */)
 	if err != nil {
 		return nil, err
 	}
@@ -336,28 +339,45 @@ func (b *localBackend) ListStacks(
 
 func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, force bool) (bool, error) {
 	stackName := stack.Ref().Name()
-	snapshot, _, err := b.getStack(stackName)
-	if err != nil {
-		return false, err
-	}
 
-	// Don't remove stacks that still have resources.
-	if !force && snapshot != nil && len(snapshot.Resources) > 0 {
-		return true, errors.New("refusing to remove stack because it still contains resources")
-	}
+	hasResources := false
+	err := b.withLock(ctx, stackName, func() error {
+		snapshot, _, err := b.getStack(stackName)
+		if err != nil {
+			return err
+		}
+
+		// Don't remove stacks that still have resources.
+		if !force && snapshot != nil && len(snapshot.Resources) > 0 {
+			hasResources = true
+			return errors.New("refusing to remove stack because it still contains resources")
+		}
 
-	return false, b.removeStack(stackName)
+		return b.removeStack(stackName)
+	})
+	return hasResources, err
 }
 
 func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack, newName tokens.QName) error {
 	stackName := stack.Ref().Name()
+
+	// Lock both names, so that neither stack can be updated while its checkpoint is moved.
+	return b.withLock(ctx, stackName, func() error {
+		return b.withLock(ctx, newName, func() error {
+			return b.renameStack(ctx, stackName, newName)
+		})
+	})
+}
+
+// renameStack renames a stack. The caller must hold the locks for both the old and the new name.
+func (b *localBackend) renameStack(ctx context.Context, stackName, newName tokens.QName) error {
 	snap, _, err := b.getStack(stackName)
 	if err != nil {
 		return err
 	}
 
 	// Ensure the destination stack does not already exist.
-	hasExisting, err := b.bucket.Exists(ctx, b.stackPath(newName))
+	hasExisting, err := b.bucket.Exists(ctx, b.checkpointPath(newName))
 	if err != nil {
 		return err
 	}
@@ -373,12 +393,12 @@ func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack, new
 	}
 
 	// Now save the snapshot with a new name (we pass nil to re-use the existing secrets manager from the snapshot).
-	if _, err = b.saveStack(newName, snap, nil); err != nil {
+	if _, err = b.saveStack(newName, snap, nil, true /* backup */); err != nil {
 		return err
 	}
 
 	// To remove the old stack, just make a backup of the file and don't write out anything new.
-	file := b.stackPath(stackName)
+	file := b.checkpointPath(stackName)
 	backupTarget(b.bucket, file)
 
 	// And rename the histoy folder as well.
@@ -458,6 +478,19 @@ func (b *localBackend) apply(
 			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
 	}
 
+	// Lock the stack so that no other update can modify it concurrently. Previews don't write state, so they don't
+	// need the lock.
+	if !opts.DryRun {
+		if err := b.lock(ctx, stackName); err != nil {
+			return nil, result.FromError(err)
+		}
+		defer func() {
+			if err := b.unlock(context.TODO(), stackName); err != nil {
+				cmdutil.Diag().Warningf(diag.Message("", "Could not unlock stack: %v"), err)
+			}
+		}()
+	}
+
 	// Start the update.
 	update, err := b.newUpdate(stackName, op)
 	if err != nil {
@@ -575,10 +608,10 @@ func (b *localBackend) apply(
 		var link string
 		if strings.HasPrefix(b.url, FilePathPrefix) {
 			u, _ := url.Parse(b.url)
-			u.Path = filepath.ToSlash(path.Join(u.Path, b.stackPath(stackName)))
+			u.Path = filepath.ToSlash(path.Join(u.Path, b.checkpointPath(stackName)))
 			link = u.String()
 		} else {
-			link, err = b.bucket.SignedURL(context.TODO(), b.stackPath(stackName), nil)
+			link, err = b.bucket.SignedURL(context.TODO(), b.checkpointPath(stackName), nil)
 			if err != nil {
 				// we log a warning here rather then returning an error to avoid exiting
 				// pulumi with an error code.
@@ -687,8 +720,10 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 		return err
 	}
 
-	_, err = b.saveStack(stackName, snap, snap.SecretsManager)
-	return err
+	return b.withLock(ctx, stackName, func() error {
+		_, err := b.saveStack(stackName, snap, snap.SecretsManager, true /* backup */)
+		return err
+	})
 }
 
 func (b *localBackend) Logout() error {
@@ -705,6 +740,7 @@ func (b *localBackend) CurrentUser() (string, error) {
 
 func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 	var stacks []tokens.QName
+	seen := make(map[tokens.QName]bool)
 
 	// Read the stack directory.
 	path := b.stackPath("")
@@ -720,15 +756,23 @@ func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 			continue
 		}
 
-		// Skip files without valid extensions (e.g., *.bak files).
+		// Skip files without valid extensions (e.g., *.bak files).  Compressed checkpoints end in .json.gz.
 		stackfn := objectName(file)
-		ext := filepath.Ext(stackfn)
-		if _, has := encoding.Marshalers[ext]; !has {
+		if filepath.Ext(stackfn) == "" {
+			continue
+		}
+		m, ext := encoding.Detect(stackfn)
+		if m == nil {
 			continue
 		}
 
-		// Read in this stack's information.
+		// Read in this stack's information, unless it has both a compressed and an uncompressed checkpoint and the
+		// other has already been read.
 		name := tokens.QName(stackfn[:len(stackfn)-len(ext)])
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
 		_, _, err := b.getStack(name)
 		if err != nil {
 			logging.V(5).Infof("error reading stack: %v (%v) skipping", name, err)
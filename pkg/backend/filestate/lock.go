@@ -0,0 +1,145 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// lockContent is the content of a stack's lock file. It records who holds the lock so that anyone who finds the
+// stack locked can tell who to talk to, or whether the lock is stale.
+type lockContent struct {
+	Pid       int       `json:"pid"`
+	Username  string    `json:"username"`
+	Hostname  string    `json:"hostname"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// lockPath returns the path of the lock file for the given stack, which lives next to its checkpoint file.
+func (b *localBackend) lockPath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return b.stackPath(stack) + ".lock"
+}
+
+// lock acquires the lock for the given stack, failing if another process already holds it. The lock is advisory and
+// is only honored by other local backends.
+//
+// Acquiring the lock is not atomic. The version of gocloud.dev that backs the bucket offers no conditional write, so
+// the lock is acquired by checking that the lock file does not exist, writing it, and reading it back to check that
+// no other process overwrote it in the meantime. This narrows the race between two processes that lock the same stack
+// at the same instant, but does not close it: if both processes write the lock file before either reads it back, the
+// later write wins both checks and both processes proceed.
+func (b *localBackend) lock(ctx context.Context, stack tokens.QName) error {
+	lockPath := b.lockPath(stack)
+
+	exists, err := b.bucket.Exists(ctx, lockPath)
+	if err != nil {
+		return errors.Wrap(err, "checking for an existing lock")
+	}
+	if exists {
+		return b.lockedError(ctx, stack)
+	}
+
+	content := lockContent{
+		Pid:       os.Getpid(),
+		Timestamp: time.Now(),
+	}
+	if content.Username, err = b.CurrentUser(); err != nil {
+		content.Username = "<unknown>"
+	}
+	if content.Hostname, err = os.Hostname(); err != nil {
+		content.Hostname = "<unknown>"
+	}
+
+	byts, err := json.Marshal(content)
+	if err != nil {
+		return errors.Wrap(err, "marshalling lock")
+	}
+	if err = b.bucket.WriteAll(ctx, lockPath, byts, nil); err != nil {
+		return errors.Wrap(err, "writing lock")
+	}
+
+	// If another process wrote the lock file after our existence check, it holds the lock.
+	written, err := b.bucket.ReadAll(ctx, lockPath)
+	if err != nil {
+		return errors.Wrap(err, "reading lock")
+	}
+	if !bytes.Equal(written, byts) {
+		return b.lockedError(ctx, stack)
+	}
+	return nil
+}
+
+// lockedError returns an error describing who holds the lock for the given stack.
+func (b *localBackend) lockedError(ctx context.Context, stack tokens.QName) error {
+	const hint = "if no update is in progress, run `pulumi force-unlock` to remove the lock"
+
+	var content lockContent
+	byts, err := b.bucket.ReadAll(ctx, b.lockPath(stack))
+	if err != nil || json.Unmarshal(byts, &content) != nil {
+		return errors.Errorf("the stack '%s' is currently locked; %s", stack, hint)
+	}
+	return errors.Errorf("the stack '%s' is currently locked by %s@%s (pid %d) since %s; %s",
+		stack, content.Username, content.Hostname, content.Pid, content.Timestamp.Format(time.RFC3339), hint)
+}
+
+// unlock releases the lock for the given stack.
+func (b *localBackend) unlock(ctx context.Context, stack tokens.QName) error {
+	if err := b.bucket.Delete(ctx, b.lockPath(stack)); err != nil {
+		return errors.Wrap(err, "removing lock")
+	}
+	return nil
+}
+
+// withLock runs f while holding the lock for the given stack, so that it cannot race with an update that is writing
+// the stack's checkpoint.
+func (b *localBackend) withLock(ctx context.Context, stack tokens.QName, f func() error) error {
+	if err := b.lock(ctx, stack); err != nil {
+		return err
+	}
+	defer func() {
+		if err := b.unlock(context.TODO(), stack); err != nil {
+			cmdutil.Diag().Warningf(diag.Message("", "Could not unlock stack: %v"), err)
+		}
+	}()
+	return f()
+}
+
+// ForceUnlock removes the lock for the given stack regardless of who holds it. It is used to clear a stale lock left
+// behind by an update that crashed or was killed.
+func (b *localBackend) ForceUnlock(ctx context.Context, stackRef backend.StackReference) error {
+	stack := stackRef.Name()
+
+	exists, err := b.bucket.Exists(ctx, b.lockPath(stack))
+	if err != nil {
+		return errors.Wrap(err, "checking for an existing lock")
+	}
+	if !exists {
+		return errors.Errorf("the stack '%s' is not locked", stack)
+	}
+	return b.unlock(ctx, stack)
+}
@@ -0,0 +1,81 @@
+package filestate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/blob/memblob"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func TestLock(t *testing.T) {
+	ctx := context.Background()
+	b := &localBackend{bucket: &wrappedBucket{bucket: memblob.OpenBucket(nil)}}
+
+	// The first lock succeeds, and a second attempt reports who holds the lock.
+	err := b.lock(ctx, "dev")
+	mustNotHaveError(t, "lock", err)
+	err = b.lock(ctx, "dev")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the stack 'dev' is currently locked by")
+		assert.Contains(t, err.Error(), "pulumi force-unlock")
+	}
+
+	// Other stacks are unaffected.
+	err = b.lock(ctx, "prod")
+	mustNotHaveError(t, "lock", err)
+
+	// Once unlocked, the stack can be locked again.
+	err = b.unlock(ctx, "dev")
+	mustNotHaveError(t, "unlock", err)
+	err = b.lock(ctx, "dev")
+	mustNotHaveError(t, "lock", err)
+
+	// Forcing the lock open clears it, and fails if there is no lock to clear.
+	err = b.ForceUnlock(ctx, localBackendReference{name: "dev"})
+	mustNotHaveError(t, "ForceUnlock", err)
+	err = b.ForceUnlock(ctx, localBackendReference{name: "dev"})
+	assert.EqualError(t, err, "the stack 'dev' is not locked")
+}
+
+func TestStackOperationsTakeLock(t *testing.T) {
+	ctx := context.Background()
+	b := &localBackend{bucket: &wrappedBucket{bucket: memblob.OpenBucket(nil)}}
+
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil)
+	_, err := b.saveStack("dev", snap, nil, true)
+	mustNotHaveError(t, "saveStack", err)
+	stack := newStack(localBackendReference{name: "dev"}, b.stackPath("dev"), snap, b)
+
+	deployment, err := b.ExportDeployment(ctx, stack)
+	mustNotHaveError(t, "ExportDeployment", err)
+
+	// While the stack is locked, operations that write its checkpoint fail.
+	err = b.lock(ctx, "dev")
+	mustNotHaveError(t, "lock", err)
+	assert.Error(t, b.ImportDeployment(ctx, stack, deployment))
+	assert.Error(t, b.RenameStack(ctx, stack, "prod"))
+	_, err = b.RemoveStack(ctx, stack, false)
+	assert.Error(t, err)
+
+	// The destination of a rename is locked as well.
+	err = b.unlock(ctx, "dev")
+	mustNotHaveError(t, "unlock", err)
+	err = b.lock(ctx, "prod")
+	mustNotHaveError(t, "lock", err)
+	assert.Error(t, b.RenameStack(ctx, stack, "prod"))
+	err = b.unlock(ctx, "prod")
+	mustNotHaveError(t, "unlock", err)
+
+	// Once the locks are released, the operations succeed and release the locks they took.
+	mustNotHaveError(t, "ImportDeployment", b.ImportDeployment(ctx, stack, deployment))
+	mustNotHaveError(t, "RenameStack", b.RenameStack(ctx, stack, "prod"))
+	for _, name := range []tokens.QName{"dev", "prod"} {
+		exists, err := b.bucket.Exists(ctx, b.lockPath(name))
+		mustNotHaveError(t, "Exists", err)
+		assert.False(t, exists)
+	}
+}
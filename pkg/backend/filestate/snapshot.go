@@ -26,6 +26,7 @@ type localSnapshotPersister struct {
 	name    tokens.QName
 	backend *localBackend
 	sm      secrets.Manager
+	saved   bool // true once the persister has saved a checkpoint, and so has backed up the previous one.
 }
 
 func (sp *localSnapshotPersister) SecretsManager() secrets.Manager {
@@ -33,7 +34,11 @@ func (sp *localSnapshotPersister) SecretsManager() secrets.Manager {
 }
 
 func (sp *localSnapshotPersister) Save(snapshot *deploy.Snapshot) error {
-	_, err := sp.backend.saveStack(sp.name, snapshot, sp.sm)
+	// Only the checkpoint from before the update is backed up; later saves overwrite the update's own checkpoints.
+	_, err := sp.backend.saveStack(sp.name, snapshot, sp.sm, !sp.saved /* backup */)
+	if err == nil {
+		sp.saved = true
+	}
 	return err
 
 }
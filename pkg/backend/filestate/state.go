@@ -27,6 +27,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v2/engine"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"gocloud.dev/gcerrors"
 
@@ -47,11 +48,24 @@ import (
 
 const DisableCheckpointBackupsEnvVar = "PULUMI_DISABLE_CHECKPOINT_BACKUPS"
 
+// GzipCheckpointsEnvVar, when set to a truthy value, causes checkpoints to be saved compressed with gzip, as
+// file.json.gz rather than file.json.  Checkpoints are read whether or not they are compressed.
+const GzipCheckpointsEnvVar = "PULUMI_GZIP_CHECKPOINTS"
+
+// DefaultCheckpointBackupCount is the number of numbered checkpoint backups (file.bak.1, file.bak.2, ...) retained
+// when the workspace's checkpointBackupCount setting is unset.
+const DefaultCheckpointBackupCount = 10
+
 // DisableIntegrityChecking can be set to true to disable checkpoint state integrity verification.  This is not
 // recommended, because it could mean proceeding even in the face of a corrupted checkpoint state file, but can
 // be used as a last resort when a command absolutely must be run.
 var DisableIntegrityChecking bool
 
+// StrictCheckpoints can be set to true to refuse to load a checkpoint that contains fields that are not part of the
+// checkpoint schema.  These are usually typos made while hand-editing the checkpoint, which would otherwise be
+// silently dropped the next time it is saved.
+var StrictCheckpoints bool
+
 type localQuery struct {
 	root string
 	proj *workspace.Project
@@ -127,7 +141,7 @@ func (b *localBackend) getStack(name tokens.QName) (*deploy.Snapshot, string, er
 		return nil, "", errors.New("invalid empty stack name")
 	}
 
-	file := b.stackPath(name)
+	file := b.checkpointPath(name)
 
 	chk, err := b.getCheckpoint(name)
 	if err != nil {
@@ -140,9 +154,14 @@ func (b *localBackend) getStack(name tokens.QName) (*deploy.Snapshot, string, er
 		return nil, "", err
 	}
 
-	// Ensure the snapshot passes verification before returning it, to catch bugs early.
+	// Ensure the snapshot passes verification before returning it, to catch bugs early.  Report every problem found,
+	// so that they can all be fixed at once.
 	if !DisableIntegrityChecking {
-		if verifyerr := snapshot.VerifyIntegrity(); verifyerr != nil {
+		if errs := snapshot.Verify(); len(errs) != 0 {
+			var verifyerr error = errs[0]
+			if len(errs) > 1 {
+				verifyerr = multierror.Append(nil, errs...)
+			}
 			return nil, file,
 				errors.Wrapf(verifyerr, "%s: snapshot integrity failure; refusing to use it", file)
 		}
@@ -153,18 +172,45 @@ func (b *localBackend) getStack(name tokens.QName) (*deploy.Snapshot, string, er
 
 // GetCheckpoint loads a checkpoint file for the given stack in this project, from the current project workspace.
 func (b *localBackend) getCheckpoint(stackName tokens.QName) (*apitype.CheckpointV3, error) {
-	chkpath := b.stackPath(stackName)
+	chkpath := b.checkpointPath(stackName)
+	m, ext := encoding.Detect(chkpath)
+	if m == nil || !m.IsJSONLike() {
+		return nil, errors.Errorf("resource deserialization failed; illegal markup extension: '%v'", ext)
+	}
 	bytes, err := b.bucket.ReadAll(context.TODO(), chkpath)
 	if err != nil {
 		return nil, err
 	}
 
+	// Decompress the checkpoint if necessary.  Decoding it into a raw message leaves the versioned checkpoint within it
+	// to be decoded below.
+	if encoding.IsCompressed(chkpath) {
+		var raw json.RawMessage
+		if err = m.Unmarshal(bytes, &raw); err != nil {
+			return nil, errors.Wrapf(err, "decompressing %s", chkpath)
+		}
+		bytes = raw
+	}
+
+	if StrictCheckpoints {
+		chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpointStrict(bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s contains unrecognized fields", chkpath)
+		}
+		return chk, nil
+	}
 	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(bytes)
 }
 
-func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm secrets.Manager) (string, error) {
+// saveStack writes the stack's checkpoint. If backup is true, the existing checkpoint is first moved to a new backup;
+// otherwise it is simply overwritten, so that an update that saves many checkpoints rotates the backups only once.
+func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm secrets.Manager,
+	backup bool) (string, error) {
 	// Make a serializable stack and then use the encoder to encode it.
 	file := b.stackPath(name)
+	if gzipCheckpoints() {
+		file += encoding.GZIPExt
+	}
 	m, ext := encoding.Detect(file)
 	if m == nil {
 		return "", errors.Errorf("resource serialization failed; illegal markup extension: '%v'", ext)
@@ -181,8 +227,12 @@ func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm se
 		return "", errors.Wrap(err, "An IO error occurred while marshalling the checkpoint")
 	}
 
-	// Back up the existing file if it already exists.
-	bck := backupTarget(b.bucket, file)
+	// Back up the existing file if it already exists.  It may have been saved with or without compression.
+	existing := b.checkpointPath(name)
+	bck := backupName(existing, 1)
+	if backup {
+		bck = backupTarget(b.bucket, existing)
+	}
 
 	// And now write out the new snapshot file, overwriting that location.
 	if err = b.bucket.WriteAll(context.TODO(), file, byts, nil); err != nil {
@@ -218,6 +268,16 @@ func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm se
 		}
 	}
 
+	// If the existing checkpoint was saved with different compression and has not been backed up, remove it so that
+	// it cannot be mistaken for the current one.
+	if existing != file {
+		if exists, err := b.bucket.Exists(context.TODO(), existing); err == nil && exists {
+			if err = b.bucket.Delete(context.TODO(), existing); err != nil {
+				return "", errors.Wrap(err, "removing the previous checkpoint file")
+			}
+		}
+	}
+
 	logging.V(7).Infof("Saved stack %s checkpoint to: %s (backup=%s)", name, file, bck)
 
 	// And if we are retaining historical checkpoint information, write it out again
@@ -246,24 +306,92 @@ func (b *localBackend) removeStack(name tokens.QName) error {
 	contract.Require(name != "", "name")
 
 	// Just make a backup of the file and don't write out anything new.
-	file := b.stackPath(name)
+	file := b.checkpointPath(name)
 	backupTarget(b.bucket, file)
 
 	historyDir := b.historyDirectory(name)
 	return removeAllByPrefix(b.bucket, historyDir)
 }
 
-// backupTarget makes a backup of an existing file, in preparation for writing a new one.  Instead of a copy, it
-// simply renames the file, which is simpler, more efficient, etc.
+// backupTarget makes a backup of an existing file, in preparation for writing a new one, retaining as many earlier
+// backups as the workspace's checkpointBackupCount setting allows.  It returns the name of the new backup.
 func backupTarget(bucket Bucket, file string) string {
+	return rotateBackupTarget(bucket, file, checkpointBackupCount())
+}
+
+// checkpointBackupCount returns the number of numbered checkpoint backups to retain.
+func checkpointBackupCount() int {
+	// Workspace settings are only available from within a project, so failing to load them is not an error.
+	if w, err := workspace.New(); err == nil {
+		if count := w.Settings().CheckpointBackupCount; count > 0 {
+			return count
+		}
+	}
+	return DefaultCheckpointBackupCount
+}
+
+// rotateBackupTarget makes a backup of an existing file as file.bak.1, first shifting any existing backups down
+// (file.bak.1 becomes file.bak.2, and so on) and pruning those that would exceed keep.  Instead of a copy, it simply
+// renames the file, which is simpler, more efficient, etc.  If the file does not exist, the existing backups are left
+// untouched.  A single file.bak left by earlier versions of the CLI is migrated into the sequence as file.bak.2.
+func rotateBackupTarget(bucket Bucket, file string, keep int) string {
 	contract.Require(file != "", "file")
-	bck := file + ".bak"
+	contract.Require(keep > 0, "keep")
+
+	bck := backupName(file, 1)
+	if exists, err := bucket.Exists(context.TODO(), file); err != nil || !exists {
+		return bck
+	}
+
+	// Migrate a legacy backup into the numbered sequence, so that the shift below moves it to file.bak.2.  If numbered
+	// backups already exist, the legacy one is older than all of them and is simply removed.
+	legacy := legacyBackupName(file)
+	if exists, err := bucket.Exists(context.TODO(), legacy); err == nil && exists {
+		if exists, err = bucket.Exists(context.TODO(), bck); err == nil && !exists {
+			err = renameObject(bucket, legacy, bck)
+		} else {
+			err = bucket.Delete(context.TODO(), legacy)
+		}
+		if err != nil {
+			logging.V(5).Infof("error migrating legacy backup: %v (%v) skipping", legacy, err)
+		}
+	}
+
+	// Prune the oldest backup to make room, along with any left over from a larger retention count.
+	for i := keep; ; i++ {
+		name := backupName(file, i)
+		if exists, err := bucket.Exists(context.TODO(), name); err != nil || !exists {
+			break
+		}
+		if err := bucket.Delete(context.TODO(), name); err != nil {
+			logging.V(5).Infof("error deleting backup: %v (%v) skipping", name, err)
+		}
+	}
+
+	// Shift the remaining backups down, oldest first, so that none are overwritten.
+	for i := keep - 1; i > 0; i-- {
+		name := backupName(file, i)
+		if exists, err := bucket.Exists(context.TODO(), name); err == nil && exists {
+			err = renameObject(bucket, name, backupName(file, i+1))
+			contract.IgnoreError(err) // ignore errors.
+		}
+	}
+
 	err := renameObject(bucket, file, bck)
 	contract.IgnoreError(err) // ignore errors.
-	// IDEA: consider multiple backups (.bak.bak.bak...etc).
 	return bck
 }
 
+// backupName returns the name of the n'th most recent backup of the given file.
+func backupName(file string, n int) string {
+	return fmt.Sprintf("%s.bak.%d", file, n)
+}
+
+// legacyBackupName returns the name of the single backup of the given file written by earlier versions of the CLI.
+func legacyBackupName(file string) string {
+	return file + ".bak"
+}
+
 // backupStack copies the current Checkpoint file to ~/.pulumi/backups.
 func (b *localBackend) backupStack(name tokens.QName) error {
 	contract.Require(name != "", "name")
@@ -274,7 +402,7 @@ func (b *localBackend) backupStack(name tokens.QName) error {
 	}
 
 	// Read the current checkpoint file. (Assuming it aleady exists.)
-	stackPath := b.stackPath(name)
+	stackPath := b.checkpointPath(name)
 	byts, err := b.bucket.ReadAll(context.TODO(), stackPath)
 	if err != nil {
 		return err
@@ -285,21 +413,48 @@ func (b *localBackend) backupStack(name tokens.QName) error {
 
 	// Write out the new backup checkpoint file.
 	stackFile := filepath.Base(stackPath)
-	ext := filepath.Ext(stackFile)
+	_, ext := encoding.Detect(stackFile)
 	base := strings.TrimSuffix(stackFile, ext)
 	backupFile := fmt.Sprintf("%s.%v%s", base, time.Now().UnixNano(), ext)
 	return b.bucket.WriteAll(context.TODO(), filepath.Join(backupDir, backupFile), byts, nil)
 }
 
+// stackPath returns the path of the given stack's uncompressed checkpoint file, or of the directory that contains
+// every stack's checkpoint if the stack is empty.  The checkpoint itself may have been saved compressed; use
+// checkpointPath to find it.
 func (b *localBackend) stackPath(stack tokens.QName) string {
 	path := filepath.Join(b.StateDir(), workspace.StackDir)
 	if stack != "" {
-		path = filepath.Join(path, fsutil.QnamePath(stack)+".json")
+		path = filepath.Join(path, fsutil.QnamePath(stack)+encoding.JSONExt)
 	}
 
 	return path
 }
 
+// checkpointPath returns the path of the given stack's checkpoint file, which is compressed if its name ends in
+// encoding.GZIPExt.  If both a compressed and an uncompressed checkpoint exist, the one matching the current
+// GzipCheckpointsEnvVar setting is preferred.  If neither exists, the uncompressed path is returned.
+func (b *localBackend) checkpointPath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+
+	path := b.stackPath(stack)
+	candidates := []string{path, path + encoding.GZIPExt}
+	if gzipCheckpoints() {
+		candidates[0], candidates[1] = candidates[1], candidates[0]
+	}
+	for _, candidate := range candidates {
+		if exists, err := b.bucket.Exists(context.TODO(), candidate); err == nil && exists {
+			return candidate
+		}
+	}
+	return path
+}
+
+// gzipCheckpoints returns true if checkpoints should be saved compressed.
+func gzipCheckpoints() bool {
+	return cmdutil.IsTruthy(os.Getenv(GzipCheckpointsEnvVar))
+}
+
 func (b *localBackend) historyDirectory(stack tokens.QName) string {
 	contract.Require(stack != "", "stack")
 	return filepath.Join(b.StateDir(), workspace.HistoryDir, fsutil.QnamePath(stack))
@@ -411,6 +566,8 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 	}
 
 	// Make a copy of the checkpoint file. (Assuming it already exists.)
-	checkpointFile := fmt.Sprintf("%s.checkpoint.json", pathPrefix)
-	return b.bucket.Copy(context.TODO(), checkpointFile, b.stackPath(name), nil)
+	stackPath := b.checkpointPath(name)
+	_, ext := encoding.Detect(stackPath)
+	checkpointFile := fmt.Sprintf("%s.checkpoint%s", pathPrefix, ext)
+	return b.bucket.Copy(context.TODO(), checkpointFile, stackPath, nil)
 }
@@ -0,0 +1,230 @@
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/blob/memblob"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func TestRotateBackupTarget(t *testing.T) {
+	ctx := context.Background()
+	bucket := &wrappedBucket{bucket: memblob.OpenBucket(nil)}
+	file := ".pulumi/stacks/dev.json"
+
+	// Backing up a file that does not exist does nothing.
+	assert.Equal(t, file+".bak.1", rotateBackupTarget(bucket, file, 3))
+	exists, err := bucket.Exists(ctx, file+".bak.1")
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+
+	// Leave a backup over from a larger retention count; it should be pruned.
+	err = bucket.WriteAll(ctx, file+".bak.4", []byte("stale"), nil)
+	mustNotHaveError(t, "WriteAll", err)
+
+	// Write and back up five versions of the file, retaining three backups.
+	for i := 1; i <= 5; i++ {
+		err = bucket.WriteAll(ctx, file, []byte(fmt.Sprintf("version %d", i)), nil)
+		mustNotHaveError(t, "WriteAll", err)
+		assert.Equal(t, file+".bak.1", rotateBackupTarget(bucket, file, 3))
+	}
+
+	exists, err = bucket.Exists(ctx, file)
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+	for n, version := range map[int]int{1: 5, 2: 4, 3: 3} {
+		contents, err := bucket.ReadAll(ctx, backupName(file, n))
+		mustNotHaveError(t, "ReadAll", err)
+		assert.Equal(t, fmt.Sprintf("version %d", version), string(contents))
+	}
+	exists, err = bucket.Exists(ctx, file+".bak.4")
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+}
+
+func TestRotateBackupTargetMigratesLegacyBackup(t *testing.T) {
+	ctx := context.Background()
+	bucket := &wrappedBucket{bucket: memblob.OpenBucket(nil)}
+	file := ".pulumi/stacks/dev.json"
+
+	// The first rotation moves a backup written by an earlier CLI to file.bak.2, behind the new file.bak.1.
+	err := bucket.WriteAll(ctx, legacyBackupName(file), []byte("legacy"), nil)
+	mustNotHaveError(t, "WriteAll", err)
+	err = bucket.WriteAll(ctx, file, []byte("current"), nil)
+	mustNotHaveError(t, "WriteAll", err)
+	assert.Equal(t, file+".bak.1", rotateBackupTarget(bucket, file, 3))
+
+	for n, expected := range map[int]string{1: "current", 2: "legacy"} {
+		contents, err := bucket.ReadAll(ctx, backupName(file, n))
+		mustNotHaveError(t, "ReadAll", err)
+		assert.Equal(t, expected, string(contents))
+	}
+	exists, err := bucket.Exists(ctx, legacyBackupName(file))
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+
+	// A legacy backup alongside numbered ones is older than all of them, so it is deleted.
+	err = bucket.WriteAll(ctx, legacyBackupName(file), []byte("stale"), nil)
+	mustNotHaveError(t, "WriteAll", err)
+	err = bucket.WriteAll(ctx, file, []byte("next"), nil)
+	mustNotHaveError(t, "WriteAll", err)
+	rotateBackupTarget(bucket, file, 3)
+
+	for n, expected := range map[int]string{1: "next", 2: "current", 3: "legacy"} {
+		contents, err := bucket.ReadAll(ctx, backupName(file, n))
+		mustNotHaveError(t, "ReadAll", err)
+		assert.Equal(t, expected, string(contents))
+	}
+	exists, err = bucket.Exists(ctx, legacyBackupName(file))
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+}
+
+func TestSnapshotPersisterBacksUpOncePerUpdate(t *testing.T) {
+	ctx := context.Background()
+	b := &localBackend{bucket: &wrappedBucket{bucket: memblob.OpenBucket(nil)}}
+	file := b.stackPath("dev")
+
+	err := b.bucket.WriteAll(ctx, file, []byte("before"), nil)
+	mustNotHaveError(t, "WriteAll", err)
+
+	// An update saves many checkpoints, but only the one from before the update is backed up.
+	persister := b.newSnapshotPersister("dev", nil)
+	for i := 0; i < 3; i++ {
+		mustNotHaveError(t, "Save", persister.Save(nil))
+	}
+	contents, err := b.bucket.ReadAll(ctx, backupName(file, 1))
+	mustNotHaveError(t, "ReadAll", err)
+	assert.Equal(t, "before", string(contents))
+	exists, err := b.bucket.Exists(ctx, backupName(file, 2))
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+
+	// The next update backs up the checkpoint written by the previous one.
+	persister = b.newSnapshotPersister("dev", nil)
+	mustNotHaveError(t, "Save", persister.Save(nil))
+	contents, err = b.bucket.ReadAll(ctx, backupName(file, 2))
+	mustNotHaveError(t, "ReadAll", err)
+	assert.Equal(t, "before", string(contents))
+	contents, err = b.bucket.ReadAll(ctx, backupName(file, 1))
+	mustNotHaveError(t, "ReadAll", err)
+	assert.NotEqual(t, "before", string(contents))
+	exists, err = b.bucket.Exists(ctx, backupName(file, 3))
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+}
+
+func TestGetStackReportsEveryIntegrityError(t *testing.T) {
+	b := &localBackend{bucket: &wrappedBucket{bucket: memblob.OpenBucket(nil)}}
+
+	// A's parent is missing, and B depends on a missing resource.
+	newResource := func(name string) *resource.State {
+		return &resource.State{
+			Type: "test",
+			URN:  resource.NewURN("dev", "proj", "", "test", tokens.QName(name)),
+		}
+	}
+	resourceA, resourceB := newResource("a"), newResource("b")
+	resourceA.Parent = newResource("missing-parent").URN
+	resourceB.Dependencies = []resource.URN{newResource("missing-dependency").URN}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{resourceA, resourceB}, nil)
+
+	// Saving the snapshot writes it before failing verification.
+	_, err := b.saveStack("dev", snap, nil, false)
+	assert.Error(t, err)
+
+	_, _, err = b.getStack("dev")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "missing parent "+string(newResource("missing-parent").URN))
+		assert.Contains(t, err.Error(), "dependency "+string(newResource("missing-dependency").URN))
+	}
+}
+
+func TestSaveAndLoadGzippedStack(t *testing.T) {
+	ctx := context.Background()
+	b := &localBackend{bucket: &wrappedBucket{bucket: memblob.OpenBucket(nil)}}
+	file := b.stackPath("dev")
+
+	res := &resource.State{
+		Type: "test",
+		URN:  resource.NewURN("dev", "proj", "", "test", "a"),
+	}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{res}, nil)
+
+	// An uncompressed checkpoint is replaced by a compressed one.
+	_, err := b.saveStack("dev", snap, nil, true)
+	mustNotHaveError(t, "saveStack", err)
+
+	os.Setenv(GzipCheckpointsEnvVar, "true")
+	defer os.Unsetenv(GzipCheckpointsEnvVar)
+	saved, err := b.saveStack("dev", snap, nil, true)
+	mustNotHaveError(t, "saveStack", err)
+	assert.Equal(t, file+".gz", saved)
+
+	exists, err := b.bucket.Exists(ctx, file)
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+	contents, err := b.bucket.ReadAll(ctx, file+".gz")
+	mustNotHaveError(t, "ReadAll", err)
+	assert.Equal(t, []byte{0x1f, 0x8b}, contents[:2])
+
+	// The compressed checkpoint is read whether or not compression is configured.
+	for _, gzip := range []string{"true", "false"} {
+		os.Setenv(GzipCheckpointsEnvVar, gzip)
+		loaded, path, err := b.getStack("dev")
+		mustNotHaveError(t, "getStack", err)
+		assert.Equal(t, file+".gz", path)
+		if assert.Len(t, loaded.Resources, 1) {
+			assert.Equal(t, res.URN, loaded.Resources[0].URN)
+		}
+	}
+
+	stacks, err := b.getLocalStacks()
+	mustNotHaveError(t, "getLocalStacks", err)
+	assert.Equal(t, []tokens.QName{"dev"}, stacks)
+
+	// Once compression is disabled again, the next save replaces the compressed checkpoint.
+	saved, err = b.saveStack("dev", snap, nil, true)
+	mustNotHaveError(t, "saveStack", err)
+	assert.Equal(t, file, saved)
+	exists, err = b.bucket.Exists(ctx, file+".gz")
+	mustNotHaveError(t, "Exists", err)
+	assert.False(t, exists)
+}
+
+func TestGetStackStrictCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	b := &localBackend{bucket: &wrappedBucket{bucket: memblob.OpenBucket(nil)}}
+	file := b.stackPath("dev")
+
+	// Save a valid checkpoint, then add a field that is not part of the checkpoint schema.
+	_, err := b.saveStack("dev", deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil), nil, false)
+	mustNotHaveError(t, "saveStack", err)
+	contents, err := b.bucket.ReadAll(ctx, file)
+	mustNotHaveError(t, "ReadAll", err)
+	var chk map[string]interface{}
+	mustNotHaveError(t, "Unmarshal", json.Unmarshal(contents, &chk))
+	chk["checkpont"] = chk["checkpoint"]
+	contents, err = json.Marshal(chk)
+	mustNotHaveError(t, "Marshal", err)
+	mustNotHaveError(t, "WriteAll", b.bucket.WriteAll(ctx, file, contents, nil))
+
+	// The checkpoint loads as usual, unless strict checking is enabled.
+	_, _, err = b.getStack("dev")
+	assert.NoError(t, err)
+
+	StrictCheckpoints = true
+	defer func() { StrictCheckpoints = false }()
+	_, _, err = b.getStack("dev")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Unrecognized field 'checkpont'")
+	}
+}
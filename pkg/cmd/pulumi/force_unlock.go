@@ -0,0 +1,104 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/backend/filestate"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newForceUnlockCmd() *cobra.Command {
+	var yes bool
+	var stack string
+	var cmd = &cobra.Command{
+		Use:   "force-unlock [<stack-name>]",
+		Args:  cmdutil.MaximumNArgs(1),
+		Short: "Remove the lock held by a stack's current update",
+		Long: "Remove the lock held by a stack's current update.\n" +
+			"\n" +
+			"Stacks managed by a local or self-hosted backend are locked while they are being updated,\n" +
+			"so that concurrent updates fail rather than corrupt the stack's state. This command\n" +
+			"removes that lock regardless of who holds it. Use it to clear a stale lock left behind\n" +
+			"by an update that crashed or was killed.\n" +
+			"\n" +
+			"Note that this operation is _very dangerous_: if the update that holds the lock is\n" +
+			"still running, another update may corrupt the stack's state.",
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			yes = yes || skipConfirmations()
+			if !yes && !cmdutil.Interactive() {
+				return result.Error("--yes must be passed in to proceed when running in non-interactive mode")
+			}
+
+			// Use the stack provided or, if missing, default to the current one.
+			if len(args) > 0 {
+				if stack != "" {
+					return result.Error("only one of --stack or argument stack name may be specified, not both")
+				}
+
+				stack = args[0]
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stack, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			// Only local and self-hosted backends lock stacks this way.
+			backend, ok := s.Backend().(filestate.Backend)
+			if !ok {
+				return result.Error("the `force-unlock` command is only supported for local stacks")
+			}
+
+			// Ensure the user really wants to do this.
+			stackName := string(s.Ref().Name())
+			prompt := fmt.Sprintf("This will remove the lock held by the current update for '%s'!", stackName)
+			if !yes && !confirmPrompt(prompt, stackName, opts) {
+				fmt.Println("confirmation declined")
+				return result.Bail()
+			}
+
+			// Remove the lock.
+			if err := backend.ForceUnlock(commandContext(), s.Ref()); err != nil {
+				return result.FromError(err)
+			}
+
+			msg := fmt.Sprintf("%sThe lock for '%s' has been removed!%s", colors.SpecAttention, stackName, colors.Reset)
+			fmt.Println(opts.Color.Colorize(msg))
+
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with removing the lock anyway")
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
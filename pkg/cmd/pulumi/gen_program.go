@@ -0,0 +1,136 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/dotnet"
+	go_gen "github.com/pulumi/pulumi/pkg/v2/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/nodejs"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/python"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// programGenerators maps each language accepted by `pulumi gen-program` to its program generator.
+var programGenerators = map[string]func(*hcl2.Program) (map[string][]byte, hcl.Diagnostics, error){
+	"dotnet": dotnet.GenerateProgram,
+	"go":     go_gen.GenerateProgram,
+	"nodejs": nodejs.GenerateProgram,
+	"python": python.GenerateProgram,
+}
+
+// newGenProgramCmd returns a new command that, when run, generates a program in the given language from a set of
+// HCL2 program files. It is hidden by default since the HCL2 program format is still experimental.
+func newGenProgramCmd() *cobra.Command {
+	var outputDir string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "gen-program <LANGUAGE> [FILES...]",
+		Args:  cmdutil.ArgsFunc(cobra.MinimumNArgs(1)),
+		Short: "Generate a program in the given language from HCL2 program files",
+		Long: "Generate a program in the given language from HCL2 program files.\n" +
+			"\n" +
+			"The language must be one of dotnet, go, nodejs, or python. If no files are given, every .pp\n" +
+			"file in the current directory is used. The generated files are written to --output-dir,\n" +
+			"which is created if necessary. Existing files are only overwritten if they were written by\n" +
+			"an earlier run and have not been edited since, unless --force is passed. The generated\n" +
+			"files are recorded in a .pulumi-generated.json manifest in --output-dir.",
+		Hidden: true,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			paths := args[1:]
+			if len(paths) == 0 {
+				matches, err := filepath.Glob("*.pp")
+				if err != nil {
+					return err
+				}
+				if len(matches) == 0 {
+					return errors.New("no .pp files found in the current directory")
+				}
+				paths = matches
+			}
+
+			return genProgram(os.Stderr, args[0], paths, outputDir, force)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&outputDir, "output-dir", ".",
+		"The directory to which the generated program is written")
+	cmd.PersistentFlags().BoolVar(
+		&force, "force", false,
+		"Overwrite existing files even if they were not generated by an earlier run or have been edited since")
+
+	return cmd
+}
+
+// genProgram parses and binds the HCL2 program files at the given paths, generates a program in the given language,
+// and writes it to outputDir. Any diagnostics are written to diagWriter.
+func genProgram(diagWriter io.Writer, language string, paths []string, outputDir string, force bool) error {
+	generate, ok := programGenerators[language]
+	if !ok {
+		return errors.Errorf("unsupported language %q; expected one of dotnet, go, nodejs, or python", language)
+	}
+
+	parser := syntax.NewParser()
+	for _, path := range paths {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err = parser.ParseFile(bytes.NewReader(contents), filepath.Base(path)); err != nil {
+			return errors.Wrapf(err, "parsing %v", path)
+		}
+	}
+	diagnostics := parser.NewDiagnosticWriter(diagWriter, 0, cmdutil.GetGlobalColorization() != colors.Never)
+	if parser.Diagnostics.HasErrors() {
+		contract.IgnoreError(diagnostics.WriteDiagnostics(parser.Diagnostics))
+		return errors.New("failed to parse the program")
+	}
+
+	program, diags, err := hcl2.BindProgram(parser.Files)
+	if err != nil {
+		return errors.Wrap(err, "binding the program")
+	}
+	contract.IgnoreError(diagnostics.WriteDiagnostics(diags))
+	if diags.HasErrors() {
+		return errors.New("failed to bind the program")
+	}
+
+	files, diags, err := generate(program)
+	if err != nil {
+		return errors.Wrap(err, "generating the program")
+	}
+	contract.IgnoreError(diagnostics.WriteDiagnostics(diags))
+	if diags.HasErrors() {
+		return errors.New("failed to generate the program")
+	}
+
+	return codegen.WriteProgram(files, outputDir, force)
+}
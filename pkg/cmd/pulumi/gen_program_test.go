@@ -0,0 +1,60 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenProgram(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gen-program")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "main.pp")
+	program := "config name string {\n}\n\noutput greeting {\n\tvalue = \"Hello, ${name}!\"\n}\n"
+	err = ioutil.WriteFile(source, []byte(program), 0600)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// The program is written under the output directory, which is created if necessary.
+	out := filepath.Join(dir, "out")
+	var diags bytes.Buffer
+	assert.NoError(t, genProgram(&diags, "nodejs", []string{source}, out, false))
+	generated, err := ioutil.ReadFile(filepath.Join(out, "index.ts"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(generated), "greeting")
+
+	// Regenerating over the unedited output is allowed.
+	assert.NoError(t, genProgram(&diags, "nodejs", []string{source}, out, false))
+
+	// Once the output has been edited, it is only overwritten with --force.
+	err = ioutil.WriteFile(filepath.Join(out, "index.ts"), []byte("// edited\n"), 0600)
+	assert.NoError(t, err)
+	assert.Error(t, genProgram(&diags, "nodejs", []string{source}, out, false))
+	assert.NoError(t, genProgram(&diags, "nodejs", []string{source}, out, true))
+
+	// Unknown languages are rejected.
+	assert.Error(t, genProgram(&diags, "cobol", []string{source}, out, false))
+}
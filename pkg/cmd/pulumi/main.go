@@ -24,6 +24,17 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 )
 
+// The exit codes used by `pulumi preview --diff-exit-code` when changes are proposed and when the preview fails.
+const (
+	previewChangesExitCode = 2
+	previewErrorExitCode   = 1
+)
+
+// exitCode is the code with which the CLI exits after a command completes successfully. Commands that need to signal
+// a non-error outcome to their caller (e.g. a preview that proposes changes) set this rather than calling os.Exit
+// directly so that post-run hooks still run.
+var exitCode = 0
+
 func panicHandler() {
 	if panicPayload := recover(); panicPayload != nil {
 		stack := string(debug.Stack())
@@ -50,4 +61,7 @@ func main() {
 		contract.IgnoreError(err)
 		os.Exit(1)
 	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
 }
@@ -47,6 +47,10 @@ func newPluginRmCmd() *cobra.Command {
 			"using the plugin install command.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			yes = yes || skipConfirmations()
+			if !yes && !cmdutil.Interactive() {
+				return errors.New("--yes must be passed in to proceed when running in non-interactive mode")
+			}
+
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
 			}
@@ -29,6 +29,7 @@ import (
 func newPreviewCmd() *cobra.Command {
 	var debug bool
 	var expectNop bool
+	var diffExitCode bool
 	var message string
 	var stack string
 	var configArray []string
@@ -39,13 +40,21 @@ func newPreviewCmd() *cobra.Command {
 	var policyPackPaths []string
 	var policyPackConfigPaths []string
 	var diffDisplay bool
+	var changesOnly bool
+	var dotCluster bool
+	var dotGraphPath string
 	var eventLogPath string
+	var filters []string
+	var full bool
+	var maxValueLength int
 	var parallel int
 	var refresh bool
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
+	var showDependencies bool
 	var showReads bool
+	var showStats bool
 	var suppressOutputs bool
 	var targets []string
 	var replaces []string
@@ -69,23 +78,41 @@ func newPreviewCmd() *cobra.Command {
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
-		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+		Run: cmdutil.RunResultFuncWithExitCode(func() int {
+			return previewExitCode(diffExitCode, true /*failed*/, false /*hasChanges*/)
+		}, func(cmd *cobra.Command, args []string) result.Result {
 			var displayType = display.DisplayProgress
 			if diffDisplay {
 				displayType = display.DisplayDiff
 			}
 
+			if full {
+				maxValueLength = 0
+			}
+
+			compiledFilters, err := display.CompileFilters(filters)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			displayOpts := display.Options{
 				Color:                cmdutil.GetGlobalColorization(),
 				ShowConfig:           showConfig,
 				ShowReplacementSteps: showReplacementSteps,
 				ShowSameResources:    showSames,
 				ShowReads:            showReads,
+				ShowDependencies:     showDependencies,
+				ShowStats:            showStats,
+				ChangesOnly:          changesOnly,
+				Filters:              compiledFilters,
+				MaxValueLength:       maxValueLength,
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        cmdutil.Interactive(),
 				Type:                 displayType,
 				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
+				DotGraphPath:         dotGraphPath,
+				DotGraphClusters:     dotCluster,
 				Debug:                debug,
 			}
 
@@ -148,6 +175,7 @@ func newPreviewCmd() *cobra.Command {
 					UseLegacyDiff:    useLegacyDiff(),
 					UpdateTargets:    targetURNs,
 					TargetDependents: targetDependents,
+					DiffStats:        showStats,
 				},
 				Display: displayOpts,
 			}
@@ -168,6 +196,8 @@ func newPreviewCmd() *cobra.Command {
 			case expectNop && changes != nil && changes.HasChanges():
 				return result.FromError(errors.New("error: no changes were expected but changes were proposed"))
 			default:
+				// Exiting here would skip Cobra's post-run hooks, so record the exit code for main to use instead.
+				exitCode = previewExitCode(diffExitCode, false /*failed*/, changes != nil && changes.HasChanges())
 				return nil
 			}
 		}),
@@ -179,6 +209,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&expectNop, "expect-no-changes", false,
 		"Return an error if any changes are proposed by this preview")
+	cmd.PersistentFlags().BoolVar(
+		&diffExitCode, "diff-exit-code", false,
+		"Exit with code 2 if the preview succeeds and proposes changes, 0 if it proposes none, and 1 if it fails")
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
@@ -221,6 +254,26 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVar(
+		&changesOnly, "changes-only", false,
+		"When displaying a rich diff, only show the paths of changed properties along with their old and new values")
+	cmd.PersistentFlags().StringVar(
+		&dotGraphPath, "dot", "",
+		"Write a DOT graph of the update's steps, colored by operation and connected by dependencies, to this file")
+	cmd.PersistentFlags().BoolVar(
+		&dotCluster, "dot-cluster", false,
+		"Group the resources in the --dot graph into clusters by package")
+	cmd.PersistentFlags().StringSliceVar(
+		&filters, "filter", []string{},
+		"Only display resources whose type or name match this glob pattern, e.g. 'aws:ec2/*' or 'aws:*'. Other "+
+			"resources are still updated, and --json output is not filtered. Multiple filters can be specified using "+
+			"--filter pat1 --filter pat2")
+	cmd.PersistentFlags().BoolVar(
+		&full, "full", false,
+		"Display property values in full, regardless of --max-value-length")
+	cmd.PersistentFlags().IntVar(
+		&maxValueLength, "max-value-length", display.DefaultMaxValueLength,
+		"Truncate string property values longer than this many characters in the display (0 to never truncate)")
 	cmd.Flags().BoolVarP(
 		&jsonDisplay, "json", "j", false,
 		"Serialize the preview diffs, operations, and overall output as JSON")
@@ -240,9 +293,16 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSames, "show-sames", false,
 		"Show resources that needn't be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showDependencies, "show-dependencies", false,
+		"When displaying a rich diff, show the resources that each resource depends on")
 	cmd.PersistentFlags().BoolVar(
 		&showReads, "show-reads", false,
 		"Show resources that are being read in, alongside those being managed directly in the stack")
+	cmd.PersistentFlags().BoolVar(
+		&showStats, "stats", false,
+		"Show statistics about the stack's resource dependency graph after the summary, and the number of property "+
+			"comparisons made while diffing each resource")
 
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
@@ -255,3 +315,19 @@ func newPreviewCmd() *cobra.Command {
 	}
 	return cmd
 }
+
+// previewExitCode returns the code with which `pulumi preview` exits. Without --diff-exit-code, a failed preview exits
+// with the standard error exit code and a successful one exits with 0. With it, a failed preview exits with 1 and a
+// successful one exits with 2 if it proposes changes and 0 otherwise.
+func previewExitCode(diffExitCode, failed, hasChanges bool) int {
+	switch {
+	case failed && diffExitCode:
+		return previewErrorExitCode
+	case failed:
+		return cmdutil.ErrorExitCode
+	case diffExitCode && hasChanges:
+		return previewChangesExitCode
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func TestPreviewExitCode(t *testing.T) {
+	var tests = []struct {
+		DiffExitCode bool
+		Failed       bool
+		HasChanges   bool
+		ExitCode     int
+	}{
+		// With --diff-exit-code: no changes, changes, and failure.
+		{DiffExitCode: true, Failed: false, HasChanges: false, ExitCode: 0},
+		{DiffExitCode: true, Failed: false, HasChanges: true, ExitCode: 2},
+		{DiffExitCode: true, Failed: true, HasChanges: false, ExitCode: 1},
+
+		// Without it, the default exit codes are unchanged.
+		{DiffExitCode: false, Failed: false, HasChanges: false, ExitCode: 0},
+		{DiffExitCode: false, Failed: false, HasChanges: true, ExitCode: 0},
+		{DiffExitCode: false, Failed: true, HasChanges: false, ExitCode: cmdutil.ErrorExitCode},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%+v", test), func(t *testing.T) {
+			assert.Equal(t, test.ExitCode, previewExitCode(test.DiffExitCode, test.Failed, test.HasChanges))
+		})
+	}
+}
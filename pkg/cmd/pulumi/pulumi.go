@@ -60,6 +60,7 @@ func NewPulumiCmd() *cobra.Command {
 	var profiling string
 	var verbose int
 	var color string
+	var colorTheme string
 
 	updateCheckResult := make(chan *diag.Diag)
 
@@ -113,6 +114,9 @@ func NewPulumiCmd() *cobra.Command {
 				}
 			}
 
+			// Apply the color theme now that we know which workspace, if any, we are running in.
+			applyColorTheme(colorTheme)
+
 			logging.InitLogging(logToStderr, verbose, logFlow)
 			cmdutil.InitTracing("pulumi-cli", "pulumi", tracing)
 			if tracingHeaderFlag != "" {
@@ -166,6 +170,8 @@ func NewPulumiCmd() *cobra.Command {
 		"Enable emojis in the output")
 	cmd.PersistentFlags().BoolVar(&filestate.DisableIntegrityChecking, "disable-integrity-checking", false,
 		"Disable integrity checking of checkpoint files")
+	cmd.PersistentFlags().BoolVar(&filestate.StrictCheckpoints, "strict-checkpoints", false,
+		"Fail if a local checkpoint file contains fields that are not part of the checkpoint schema")
 	cmd.PersistentFlags().BoolVar(&logFlow, "logflow", false,
 		"Flow log settings to child processes (like plugins)")
 	cmd.PersistentFlags().BoolVar(&logToStderr, "logtostderr", false,
@@ -180,6 +186,8 @@ func NewPulumiCmd() *cobra.Command {
 		"Enable verbose logging (e.g., v=3); anything >3 is very verbose")
 	cmd.PersistentFlags().StringVar(
 		&color, "color", "auto", "Colorize output. Choices are: always, never, raw, auto")
+	cmd.PersistentFlags().StringVar(
+		&colorTheme, "color-theme", colors.DefaultTheme, "Color theme to use for output. Choices are: dark, light, mono")
 
 	// Common commands:
 	//     - Getting Started Commands:
@@ -200,6 +208,7 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.AddCommand(newPolicyCmd())
 	//     - Advanced Commands:
 	cmd.AddCommand(newCancelCmd())
+	cmd.AddCommand(newForceUnlockCmd())
 	cmd.AddCommand(newRefreshCmd())
 	cmd.AddCommand(newStateCmd())
 	//     - Other Commands:
@@ -211,6 +220,7 @@ func NewPulumiCmd() *cobra.Command {
 	// Less common, and thus hidden, commands:
 	cmd.AddCommand(newGenCompletionCmd(cmd))
 	cmd.AddCommand(newGenMarkdownCmd(cmd))
+	cmd.AddCommand(newGenProgramCmd())
 
 	// We have a set of commands that are still experimental and that we add only when PULUMI_EXPERIMENTAL is set
 	// to true.
@@ -237,6 +247,28 @@ func NewPulumiCmd() *cobra.Command {
 	return cmd
 }
 
+// applyColorTheme applies the named color theme, followed by any color overrides in the current workspace's settings.
+// Problems with either are reported as warnings, leaving the default colors in place.
+func applyColorTheme(name string) {
+	theme, ok := colors.Themes[name]
+	if !ok {
+		cmdutil.Diag().Warningf(diag.Message("", "unknown color theme '%s'; using the default theme"), name)
+		theme = colors.Themes[colors.DefaultTheme]
+	}
+	contract.AssertNoError(colors.ApplyTheme(theme))
+
+	// Workspace settings are only available from within a project, so failing to load them is not an error.
+	w, err := workspace.New()
+	if err != nil {
+		return
+	}
+	if overrides := w.Settings().ColorTheme; len(overrides) > 0 {
+		if err = colors.ApplyTheme(overrides); err != nil {
+			cmdutil.Diag().Warningf(diag.Message("", "ignoring the color theme in the workspace settings: %v"), err)
+		}
+	}
+}
+
 // checkForUpdate checks to see if the CLI needs to be updated, and if so emits a warning, as well as information
 // as to how it can be upgraded.
 func checkForUpdate() *diag.Diag {
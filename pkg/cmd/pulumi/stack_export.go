@@ -25,6 +25,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 )
 
@@ -111,6 +112,22 @@ func newStackExportCmd() *cobra.Command {
 				}
 			}
 
+			// Write the deployment, compressing it if the file's name asks for it.
+			if file != "" && encoding.IsCompressed(file) {
+				m, _ := encoding.Detect(file)
+				if m == nil || !m.IsJSONLike() {
+					return errors.Errorf("could not export deployment: '%s' must be a .json.gz file", file)
+				}
+				data, err := m.Marshal(deployment)
+				if err != nil {
+					return errors.Wrap(err, "could not export deployment")
+				}
+				if _, err = writer.Write(data); err != nil {
+					return errors.Wrap(err, "could not export deployment")
+				}
+				return nil
+			}
+
 			// Write the deployment.
 			enc := json.NewEncoder(writer)
 			enc.SetIndent("", "    ")
@@ -125,7 +142,7 @@ func newStackExportCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(
 		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
 	cmd.PersistentFlags().StringVarP(
-		&file, "file", "", "", "A filename to write stack output to")
+		&file, "file", "", "", "A filename to write stack output to. The output is compressed if it ends in .json.gz")
 	cmd.PersistentFlags().StringVarP(
 		&version, "version", "", "", "Previous stack version to export. (If unset, will export the latest.)")
 	cmd.Flags().BoolVarP(
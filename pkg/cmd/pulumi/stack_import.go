@@ -17,6 +17,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	"github.com/hashicorp/go-multierror"
@@ -24,16 +25,22 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/mapper"
 )
 
 func newStackImportCmd() *cobra.Command {
 	var force bool
 	var file string
 	var stackName string
+	var strict bool
 	cmd := &cobra.Command{
 		Use:   "import",
 		Args:  cmdutil.MaximumNArgs(0),
@@ -67,11 +74,30 @@ func newStackImportCmd() *cobra.Command {
 
 			// Read the checkpoint from stdin.  We decode this into a json.RawMessage so as not to lose any fields
 			// sent by the server that the client CLI does not recognize (enabling round-tripping).
+			m, strictM := encoding.JSON, encoding.JSONStrict
+			if file != "" && encoding.IsCompressed(file) {
+				if detected, _ := encoding.Detect(file); detected == nil || !detected.IsJSONLike() {
+					return errors.Errorf("could not import deployment: '%s' must be a .json.gz file", file)
+				}
+				m, strictM = encoding.Gzip(m), encoding.Gzip(strictM)
+			}
+			data, err := ioutil.ReadAll(reader)
+			if err != nil {
+				return errors.Wrap(err, "could not read deployment")
+			}
 			var deployment apitype.UntypedDeployment
-			if err = json.NewDecoder(reader).Decode(&deployment); err != nil {
+			if err = m.Unmarshal(data, &deployment); err != nil {
 				return err
 			}
 
+			// If requested, reject a deployment with fields that we don't recognize. These are usually typos made while
+			// hand-editing, which would otherwise be silently dropped. --strict-checkpoints implies --strict.
+			if strict || filestate.StrictCheckpoints {
+				if err = checkDeploymentFields(strictM, data); err != nil {
+					return errors.Wrap(err, "the deployment contains unrecognized fields")
+				}
+			}
+
 			// We do, however, now want to unmarshal the json.RawMessage into a real, typed deployment.  We do this so
 			// we can check that the deployment doesn't contain resources from a stack other than the selected one. This
 			// catches errors wherein someone imports the wrong stack's deployment (which can seriously hork things).
@@ -96,11 +122,17 @@ func newStackImportCmd() *cobra.Command {
 					}
 				}
 			}
-			// Validate the stack. If --force was passed, issue an error if validation fails. Otherwise, issue a warning.
-			if err := snapshot.VerifyIntegrity(); err != nil {
+			// Validate the stack, reporting every problem found. If --force was passed, issue a warning for each
+			// problem. Otherwise, issue an error.
+			for _, err := range snapshot.Verify() {
+				var urn resource.URN
 				msg := fmt.Sprintf("state file contains errors: %v", err)
+				if ierr, ok := err.(*deploy.IntegrityError); ok {
+					urn = ierr.URN
+					msg = fmt.Sprintf("state file contains errors: %v (%s check)", ierr, ierr.Check)
+				}
 				if force {
-					cmdutil.Diag().Warningf(diag.Message("", msg))
+					cmdutil.Diag().Warningf(diag.Message(urn, msg))
 				} else {
 					result = multierror.Append(result, errors.New(msg))
 				}
@@ -150,7 +182,56 @@ func newStackImportCmd() *cobra.Command {
 		&force, "force", "f", false,
 		"Force the import to occur, even if apparent errors are discovered beforehand (not recommended)")
 	cmd.PersistentFlags().StringVarP(
-		&file, "file", "", "", "A filename to read stack input from")
+		&file, "file", "", "", "A filename to read stack input from. The input is decompressed if it ends in .json.gz")
+	cmd.PersistentFlags().BoolVar(
+		&strict, "strict", false,
+		"Fail if the deployment contains fields that are not part of the deployment schema. Implied by "+
+			"--strict-checkpoints")
 
 	return cmd
 }
+
+// checkDeploymentFields returns a mapper.MappingError listing the fields of the given deployment that are not part of
+// the schema for its version, including those at the top level of the deployment. The deployment is decoded with the
+// given strict marshaler.
+func checkDeploymentFields(m encoding.Marshaler, data []byte) error {
+	var failures []error
+	addFailures := func(err error) error {
+		merr, ok := err.(mapper.MappingError)
+		if !ok {
+			return err
+		}
+		failures = append(failures, merr.Failures()...)
+		return nil
+	}
+
+	var deployment apitype.UntypedDeployment
+	if err := m.Unmarshal(data, &deployment); err != nil {
+		if err = addFailures(err); err != nil {
+			return err
+		}
+	}
+
+	var typed interface{}
+	switch deployment.Version {
+	case 1:
+		typed = &apitype.DeploymentV1{}
+	case 2:
+		typed = &apitype.DeploymentV2{}
+	case 3:
+		typed = &apitype.DeploymentV3{}
+	}
+	// Unsupported versions are reported when the deployment is deserialized.
+	if typed != nil {
+		if err := encoding.JSONStrict.Unmarshal(deployment.Deployment, typed); err != nil {
+			if err = addFailures(err); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+	return mapper.NewMappingError(failures)
+}
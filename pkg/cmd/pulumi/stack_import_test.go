@@ -0,0 +1,47 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/mapper"
+)
+
+func TestCheckDeploymentFields(t *testing.T) {
+	// A deployment without unrecognized fields passes.
+	valid := `{"version": 3, "deployment": {"manifest": {"time": "2020-01-01T00:00:00Z", "magic": "", "version": ""},
+		"resources": [{"urn": "urn:pulumi:s::p::t::n", "custom": false, "type": "t"}]}}`
+	assert.NoError(t, checkDeploymentFields(encoding.JSONStrict, []byte(valid)))
+
+	// Typos at the top level and within the deployment are all reported.
+	invalid := `{"version": 3, "deploymnet": {}, "deployment": {"manifest": {"time": "2020-01-01T00:00:00Z",
+		"magic": "", "version": ""}, "resources": [{"urn": "urn:pulumi:s::p::t::n", "custom": false, "type": "t",
+		"protcet": true}]}}`
+	err := checkDeploymentFields(encoding.JSONStrict, []byte(invalid))
+	merr, ok := err.(mapper.MappingError)
+	if assert.True(t, ok, "expected a mapper.MappingError; got %v", err) {
+		var fields []string
+		for _, failure := range merr.Failures() {
+			if assert.IsType(t, &mapper.UnrecognizedError{}, failure) {
+				fields = append(fields, failure.(*mapper.UnrecognizedError).Field())
+			}
+		}
+		assert.ElementsMatch(t, []string{"deploymnet", "protcet"}, fields)
+	}
+}
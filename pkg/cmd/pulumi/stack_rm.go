@@ -47,6 +47,10 @@ func newStackRmCmd() *cobra.Command {
 			"After this command completes, the stack will no longer be available for updates.",
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			yes = yes || skipConfirmations()
+			if !yes && !cmdutil.Interactive() {
+				return result.Error("--yes must be passed in to proceed when running in non-interactive mode")
+			}
+
 			// Use the stack provided or, if missing, default to the current one.
 			if len(args) > 0 {
 				if stack != "" {
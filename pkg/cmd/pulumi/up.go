@@ -55,12 +55,19 @@ func newUpCmd() *cobra.Command {
 	var policyPackPaths []string
 	var policyPackConfigPaths []string
 	var diffDisplay bool
+	var changesOnly bool
+	var dotCluster bool
+	var dotGraphPath string
 	var eventLogPath string
+	var filters []string
+	var full bool
+	var maxValueLength int
 	var parallel int
 	var refresh bool
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
+	var showDependencies bool
 	var showReads bool
 	var skipPreview bool
 	var suppressOutputs bool
@@ -343,6 +350,11 @@ func newUpCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
+			compiledFilters, err := display.CompileFilters(filters)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			if err = validatePolicyPackConfig(policyPackPaths, policyPackConfigPaths); err != nil {
 				return result.FromError(err)
 			}
@@ -352,16 +364,26 @@ func newUpCmd() *cobra.Command {
 				displayType = display.DisplayDiff
 			}
 
+			if full {
+				maxValueLength = 0
+			}
+
 			opts.Display = display.Options{
 				Color:                cmdutil.GetGlobalColorization(),
 				ShowConfig:           showConfig,
 				ShowReplacementSteps: showReplacementSteps,
 				ShowSameResources:    showSames,
 				ShowReads:            showReads,
+				ShowDependencies:     showDependencies,
+				ChangesOnly:          changesOnly,
+				Filters:              compiledFilters,
+				MaxValueLength:       maxValueLength,
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				DotGraphPath:         dotGraphPath,
+				DotGraphClusters:     dotCluster,
 				Debug:                debug,
 			}
 
@@ -425,6 +447,26 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVar(
+		&changesOnly, "changes-only", false,
+		"When displaying a rich diff, only show the paths of changed properties along with their old and new values")
+	cmd.PersistentFlags().StringVar(
+		&dotGraphPath, "dot", "",
+		"Write a DOT graph of the update's steps, colored by operation and connected by dependencies, to this file")
+	cmd.PersistentFlags().BoolVar(
+		&dotCluster, "dot-cluster", false,
+		"Group the resources in the --dot graph into clusters by package")
+	cmd.PersistentFlags().StringSliceVar(
+		&filters, "filter", []string{},
+		"Only display resources whose type or name match this glob pattern, e.g. 'aws:ec2/*' or 'aws:*'. Other "+
+			"resources are still updated, and --json output is not filtered. Multiple filters can be specified using "+
+			"--filter pat1 --filter pat2")
+	cmd.PersistentFlags().BoolVar(
+		&full, "full", false,
+		"Display property values in full, regardless of --max-value-length")
+	cmd.PersistentFlags().IntVar(
+		&maxValueLength, "max-value-length", display.DefaultMaxValueLength,
+		"Truncate string property values longer than this many characters in the display (0 to never truncate)")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
@@ -441,6 +483,9 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSames, "show-sames", false,
 		"Show resources that don't need be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showDependencies, "show-dependencies", false,
+		"When displaying a rich diff, show the resources that each resource depends on")
 	cmd.PersistentFlags().BoolVar(
 		&showReads, "show-reads", false,
 		"Show resources that are being read in, alongside those being managed directly in the stack")
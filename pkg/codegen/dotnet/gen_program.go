@@ -377,6 +377,29 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	if opts.IgnoreChanges != nil {
 		appendOption("IgnoreChanges", opts.IgnoreChanges)
 	}
+	if opts.DeleteBeforeReplace != nil {
+		appendOption("DeleteBeforeReplace", opts.DeleteBeforeReplace)
+	}
+	if opts.RetainOnDelete != nil {
+		g.diagnostics = append(g.diagnostics,
+			codegen.UnsupportedOption(opts.RetainOnDelete.SyntaxNode(), "retainOnDelete", "C#"))
+	}
+	if opts.Import != nil {
+		appendOption("ImportId", opts.Import)
+	}
+	if opts.Aliases != nil {
+		// Aliases must be generated as Alias objects rather than anonymous objects.
+		g.diagnostics = append(g.diagnostics,
+			codegen.UnsupportedOption(opts.Aliases.SyntaxNode(), "aliases", "C#"))
+	}
+	if opts.CustomTimeouts != nil {
+		// Custom timeouts must be generated as a CustomTimeouts object with TimeSpan fields.
+		g.diagnostics = append(g.diagnostics,
+			codegen.UnsupportedOption(opts.CustomTimeouts.SyntaxNode(), "customTimeouts", "C#"))
+	}
+	if opts.AdditionalSecretOutputs != nil {
+		appendOption("AdditionalSecretOutputs", opts.AdditionalSecretOutputs)
+	}
 
 	if result.Len() != 0 {
 		g.Indent = g.Indent[:len(g.Indent)-4]
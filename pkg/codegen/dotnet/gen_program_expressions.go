@@ -318,6 +318,9 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgen(w, "JsonSerializer.Serialize(")
 		g.genDictionary(w, expr.Args[0])
 		g.Fgen(w, ")")
+	case "can", "try":
+		// try and can are implemented by recovering from runtime errors, which is only supported by the Go generator.
+		g.genNYI(w, "call %v", expr.Name)
 	default:
 		g.genNYI(w, "call %v", expr.Name)
 	}
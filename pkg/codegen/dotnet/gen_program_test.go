@@ -32,6 +32,11 @@ func TestGenProgram(t *testing.T) {
 		if filepath.Base(f.Name()) == "aws-s3-folder.pp" {
 			expectNYIDiags = true
 		}
+		// retainOnDelete is not supported by the .NET SDK, so it is reported and omitted.
+		expectUnsupportedDiags := false
+		if filepath.Base(f.Name()) == "retain-on-delete.pp" {
+			expectUnsupportedDiags = true
+		}
 
 		t.Run(f.Name(), func(t *testing.T) {
 			path := filepath.Join(testdataPath, f.Name())
@@ -73,6 +78,15 @@ func TestGenProgram(t *testing.T) {
 				}
 				diags = tmpDiags
 			}
+			if expectUnsupportedDiags {
+				var tmpDiags hcl.Diagnostics
+				for _, d := range diags {
+					if !strings.HasPrefix(d.Summary, "the retainOnDelete resource option is not supported") {
+						tmpDiags = append(tmpDiags, d)
+					}
+				}
+				diags = tmpDiags
+			}
 			if diags.HasErrors() {
 				t.Fatalf("failed to generate program: %v", diags)
 			}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	gofmt "go/format"
 	"io"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
@@ -29,12 +33,54 @@ type generator struct {
 	readDirTempSpiller  *readDirSpiller
 	splatSpiller        *splatSpiller
 	optionalSpiller     *optionalSpiller
+	forSpiller          *forSpiller
 	scopeTraversalRoots codegen.StringSet
 	arrayHelpers        map[string]*promptToInputArrayHelper
+	identifiers         map[string]string
+	detachedComments    map[hcl2.Node]syntax.TriviaList
+	zeroValueName       string
+	configName          string
+	configParamName     string
+	configCreated       bool
+	packageVars         codegen.StringSet
+	importNames         map[string]string
 	isErrAssigned       bool
+	needsTryHelper      bool
+	needsCanHelper      bool
+	argsStrategy        ArgsStrategy
+}
+
+// GenerateProgramOptions controls the shape of a generated program and the optional formatting pass that is applied to
+// its source after gofmt.
+type GenerateProgramOptions struct {
+	// ArgsStrategy controls how resource args are constructed. If nil, PointerArgs is used.
+	ArgsStrategy ArgsStrategy
+	// ModulePath is the module path declared by the program's go.mod. If empty, "main" is used.
+	ModulePath string
+	// SplitFiles generates the resources of each provider package in a file named after the package, e.g. aws.go,
+	// rather than in main.go. Programs whose local variables are used by resources from more than one package are
+	// still generated as a single file.
+	SplitFiles bool
+
+	// GroupImports regroups the program's imports into a standard library group followed by a third-party group,
+	// with each group sorted by import path.
+	GroupImports bool
+	// MaxLineWidth, if non-zero, splits interpreted string literals on lines that are wider than the given number of
+	// characters into concatenations of shorter literals, breaking only after whitespace. Wrapping is best-effort:
+	// lines that do not contain string literals, and literals without whitespace, are left as-is.
+	MaxLineWidth int
 }
 
 func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
+	return GenerateProgramWithOptions(program, GenerateProgramOptions{})
+}
+
+// GenerateProgramWithOptions generates a Go program from the given HCL2 program. Resource args are constructed using
+// opts.ArgsStrategy, and after the generated source has been formatted with gofmt, the additional formatting pass
+// described by opts is applied. The program's main.go is returned along with a go.mod that declares opts.ModulePath
+// and, if the program has config variables, a Pulumi.yaml that declares them.
+func GenerateProgramWithOptions(program *hcl2.Program, opts GenerateProgramOptions) (
+	map[string][]byte, hcl.Diagnostics, error) {
 	// Linearize the nodes into an order appropriate for procedural code generation.
 	nodes := hcl2.Linearize(program)
 
@@ -43,6 +89,11 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 		contexts[pkg.Name] = getPackages("tool", pkg)
 	}
 
+	argsStrategy := opts.ArgsStrategy
+	if argsStrategy == nil {
+		argsStrategy = PointerArgs{}
+	}
+
 	g := &generator{
 		program:             program,
 		contexts:            contexts,
@@ -51,46 +102,82 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 		readDirTempSpiller:  &readDirSpiller{},
 		splatSpiller:        &splatSpiller{},
 		optionalSpiller:     &optionalSpiller{},
+		forSpiller:          &forSpiller{},
 		scopeTraversalRoots: codegen.NewStringSet(),
 		arrayHelpers:        make(map[string]*promptToInputArrayHelper),
+		identifiers:         make(map[string]string),
+		argsStrategy:        argsStrategy,
 	}
 
 	g.Formatter = format.NewFormatter(g)
+	g.collectIdentifiers(program)
+	g.collectDetachedComments(program)
 
 	// we must collect imports once before lowering, and once after.
 	// this allows us to avoid complexity of traversing apply expressions for things like JSON
 	// but still have access to types provided by __convert intrinsics after lowering.
-	pulumiImports := codegen.NewStringSet()
-	stdImports := codegen.NewStringSet()
-	g.collectImports(program, stdImports, pulumiImports)
-
-	var progPostamble bytes.Buffer
 	for _, n := range nodes {
 		g.collectScopeRoots(n)
 	}
 
-	for _, n := range nodes {
-		g.genNode(&progPostamble, n)
+	// Collect the imports of the whole program up front so that imports whose package names collide are aliased
+	// consistently in every generated file.
+	g.disambiguateImports(g.collectImports(program, codegen.NewStringSet(), codegen.NewStringSet()))
+
+	var sources map[string][]byte
+	if opts.SplitFiles {
+		sources = g.genSplitProgram(program, nodes)
 	}
+	if sources == nil {
+		pulumiImports := codegen.NewStringSet()
+		stdImports := codegen.NewStringSet()
+		g.collectImports(program, stdImports, pulumiImports)
+
+		var progPostamble bytes.Buffer
 
-	g.genPostamble(&progPostamble, nodes)
+		for _, n := range nodes {
+			g.genNode(&progPostamble, n)
+		}
 
-	// We must generate the program first and the preamble second and finally cat the two together.
-	// This is because nested object/tuple cons expressions can require imports that aren't
-	// present in resource declarations or invokes alone. Expressions are lowered when the program is generated
-	// and this must happen first so we can access types via __convert intrinsics.
-	var index bytes.Buffer
-	g.genPreamble(&index, program, stdImports, pulumiImports)
-	index.Write(progPostamble.Bytes())
+		g.genPostamble(&progPostamble, nodes)
+
+		// We must generate the program first and the preamble second and finally cat the two together.
+		// This is because nested object/tuple cons expressions can require imports that aren't
+		// present in resource declarations or invokes alone. Expressions are lowered when the program is generated
+		// and this must happen first so we can access types via __convert intrinsics.
+		var index bytes.Buffer
+		g.genPreamble(&index, program, stdImports, pulumiImports)
+		index.Write(progPostamble.Bytes())
+		sources = map[string][]byte{"main.go": index.Bytes()}
+	}
+
+	files := make(map[string][]byte, len(sources)+1)
+	for _, name := range codegen.SortedKeys(sources) {
+		// Run Go formatter on the code before saving to disk
+		formattedSource, err := gofmt.Source(sources[name])
+		if err != nil {
+			return nil, g.diagnostics, errors.Errorf("invalid Go source code in %s: %v\n\n%s", name, err, sources[name])
+		}
+		formattedSource, err = formatSource(formattedSource, opts)
+		if err != nil {
+			return nil, g.diagnostics, errors.Wrapf(err, "formatting Go source code in %s", name)
+		}
+		files[name] = formattedSource
+	}
 
-	// Run Go formatter on the code before saving to disk
-	formattedSource, err := gofmt.Source(index.Bytes())
+	goMod, err := g.genGoMod(program, opts.ModulePath)
 	if err != nil {
-		panic(errors.Errorf("invalid Go source code:\n\n%s", index.String()))
+		return nil, g.diagnostics, errors.Wrap(err, "generating go.mod")
 	}
 
-	files := map[string][]byte{
-		"main.go": formattedSource,
+	files["go.mod"] = goMod
+
+	project, err := g.genProject(program, opts.ModulePath)
+	if err != nil {
+		return nil, g.diagnostics, errors.Wrap(err, "generating Pulumi.yaml")
+	}
+	if project != nil {
+		files["Pulumi.yaml"] = project
 	}
 	return files, g.diagnostics, nil
 }
@@ -104,6 +191,52 @@ func getPackages(tool string, pkg *schema.Package) map[string]*pkgContext {
 	return generatePackageContextMap(tool, pkg, goInfo)
 }
 
+// collectIdentifiers assigns a unique Go identifier to each named node in the program. Names that are distinct in HCL
+// but sanitize to the same identifier (e.g. `my-res` and `my_res`) are disambiguated by appending a numeric suffix to
+// the later declaration, so the assignment is deterministic for a given program.
+func (g *generator) collectIdentifiers(program *hcl2.Program) {
+	// The names of the helpers for try and can are reserved so that program variables never shadow them.
+	taken := codegen.NewStringSet("try", "can", "attempt")
+	for _, n := range program.Nodes {
+		switch n.(type) {
+		case *hcl2.Resource, *hcl2.LocalVariable, *hcl2.ConfigVariable:
+		default:
+			continue
+		}
+
+		base := makeValidIdentifier(n.Name())
+		id := base
+		for i := 2; taken.Has(id); i++ {
+			id = fmt.Sprintf("%s%d", base, i)
+		}
+		taken.Add(id)
+		g.identifiers[n.Name()] = id
+	}
+
+	// Anonymous functions that may return early declare a temporary to hold their zero value, and config variables are
+	// read using a config object and a temporary. Derive their names from the program's identifiers so that they never
+	// shadow a program variable and are the same each time the program is generated.
+	unique := func(base string) string {
+		id := base
+		for i := 2; taken.Has(id); i++ {
+			id = fmt.Sprintf("%s%d", base, i)
+		}
+		return id
+	}
+	g.zeroValueName = unique("_zero")
+	g.configName = unique("cfg")
+	g.configParamName = unique("param")
+}
+
+// identifier returns the Go identifier assigned to the named node, or a sanitized version of the name if the node was
+// not assigned an identifier.
+func (g *generator) identifier(name string) string {
+	if id, ok := g.identifiers[name]; ok {
+		return id
+	}
+	return makeValidIdentifier(name)
+}
+
 func (g *generator) collectScopeRoots(n hcl2.Node) {
 	diags := n.VisitExpressions(nil, func(n model.Expression) (model.Expression, hcl.Diagnostics) {
 		if st, ok := n.(*model.ScopeTraversalExpression); ok {
@@ -116,10 +249,17 @@ func (g *generator) collectScopeRoots(n hcl2.Node) {
 
 // genPreamble generates package decl, imports, and opens the main func
 func (g *generator) genPreamble(w io.Writer, program *hcl2.Program, stdImports, pulumiImports codegen.StringSet) {
+	g.collectImports(program, stdImports, pulumiImports)
+	g.genFileHeader(w, stdImports, pulumiImports)
+	g.Fprintf(w, "func main() {\n")
+	g.Fprintf(w, "pulumi.Run(func(ctx *pulumi.Context) error {\n")
+}
+
+// genFileHeader generates the package decl and imports of a file.
+func (g *generator) genFileHeader(w io.Writer, stdImports, pulumiImports codegen.StringSet) {
 	g.Fprint(w, "package main\n\n")
 	g.Fprintf(w, "import (\n")
 
-	g.collectImports(program, stdImports, pulumiImports)
 	for _, imp := range stdImports.SortedValues() {
 		g.Fprintf(w, "\"%s\"\n", imp)
 	}
@@ -128,12 +268,10 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program, stdImports,
 	g.Fprintf(w, "\"github.com/pulumi/pulumi/sdk/v2/go/pulumi\"\n")
 
 	for _, imp := range pulumiImports.SortedValues() {
-		g.Fprintf(w, "%s\n", imp)
+		g.Fprintf(w, "%s\n", g.importSpec(imp))
 	}
 
 	g.Fprintf(w, ")\n")
-	g.Fprintf(w, "func main() {\n")
-	g.Fprintf(w, "pulumi.Run(func(ctx *pulumi.Context) error {\n")
 }
 
 // collect Imports returns two sets of packages imported by the program, std lib packages and pulumi packages
@@ -141,12 +279,27 @@ func (g *generator) collectImports(
 	program *hcl2.Program,
 	stdImports,
 	pulumiImports codegen.StringSet) (codegen.StringSet, codegen.StringSet) {
+	return g.collectNodeImports(program, program.Nodes, stdImports, pulumiImports)
+}
+
+// collectNodeImports adds the packages imported by the code generated for the given nodes to the given sets of std lib
+// packages and pulumi packages.
+func (g *generator) collectNodeImports(
+	program *hcl2.Program,
+	nodes []hcl2.Node,
+	stdImports,
+	pulumiImports codegen.StringSet) (codegen.StringSet, codegen.StringSet) {
 	// Accumulate import statements for the various providers
-	for _, n := range program.Nodes {
-		if r, isResource := n.(*hcl2.Resource); isResource {
+	for _, n := range nodes {
+		if v, isConfig := n.(*hcl2.ConfigVariable); isConfig && g.isConfigRead(v) {
+			pulumiImports.Add(`"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"`)
+		}
+
+		if r, isResource := n.(*hcl2.Resource); isResource && r.Token != hcl2.StackReferenceToken {
 			pkg, mod, name, _ := r.DecomposeToken()
 			if pkg == "pulumi" && mod == "providers" {
-				pkg = name
+				// Provider resources live in the root package of their SDK.
+				pkg, mod = name, ""
 			}
 
 			vPath, err := g.getVersionPath(program, pkg)
@@ -155,6 +308,11 @@ func (g *generator) collectImports(
 			}
 
 			pulumiImports.Add(g.getPulumiImport(pkg, vPath, mod))
+
+			// Ranged resources are named using fmt.Sprintf.
+			if r.Options != nil && r.Options.Range != nil && model.ResolveOutputs(r.Options.Range.Type()) != model.BoolType {
+				stdImports.Add("fmt")
+			}
 		}
 
 		diags := n.VisitExpressions(nil, func(n model.Expression) (model.Expression, hcl.Diagnostics) {
@@ -213,6 +371,10 @@ func (g *generator) collectImports(
 					stdImports.Add("fmt")
 				}
 			}
+			// For expressions over maps range over the sorted keys of the map.
+			if f, ok := n.(*model.ForExpression); ok && isMapCollection(f.Collection.Type()) {
+				stdImports.Add("sort")
+			}
 			return n, nil
 		})
 		contract.Assert(len(diags) == 0)
@@ -276,6 +438,75 @@ func (g *generator) getPulumiImport(pkg, vPath, mod string) string {
 	return fmt.Sprintf("%q", imp)
 }
 
+// disambiguateImports assigns an alias to each Pulumi import whose package name would collide with that of another
+// import. Imports from the Pulumi SDK and the standard library keep their names. Of the remaining imports, the first
+// with a given name, in sorted order, keeps it, and later imports are suffixed with v2, v3, etc. (e.g. ec2 and ec2v2).
+func (g *generator) disambiguateImports(stdImports, pulumiImports codegen.StringSet) {
+	taken := codegen.NewStringSet()
+	for _, imp := range stdImports.SortedValues() {
+		taken.Add(path.Base(imp))
+	}
+
+	var providerImports []string
+	for _, imp := range pulumiImports.SortedValues() {
+		if strings.HasPrefix(imp, `"github.com/pulumi/pulumi/sdk/`) {
+			taken.Add(importName(imp))
+		} else {
+			providerImports = append(providerImports, imp)
+		}
+	}
+	taken.Add("pulumi")
+
+	g.importNames = make(map[string]string)
+	for _, imp := range providerImports {
+		base := importName(imp)
+		name := base
+		for i := 2; taken.Has(name); i++ {
+			name = fmt.Sprintf("%sv%d", base, i)
+		}
+		taken.Add(name)
+		if name != base {
+			g.importNames[imp] = name
+		}
+	}
+}
+
+// importName returns the name by which the package imported by the given import spec is referenced: its alias if it
+// has one, or else the last element of its path.
+func importName(imp string) string {
+	if i := strings.Index(imp, " "); i != -1 {
+		return imp[:i]
+	}
+	importPath, err := strconv.Unquote(imp)
+	contract.AssertNoError(err)
+	return path.Base(importPath)
+}
+
+// importSpec returns the import spec to generate for the given import, which is aliased if its package name collides
+// with that of another import.
+func (g *generator) importSpec(imp string) string {
+	if name, ok := g.importNames[imp]; ok {
+		return fmt.Sprintf("%s %s", name, imp[strings.Index(imp, `"`):])
+	}
+	return imp
+}
+
+// renamedImport returns the alias assigned by disambiguateImports to the import of the given module, if any.
+func (g *generator) renamedImport(pkg, mod string) (string, bool) {
+	if len(g.importNames) == 0 {
+		return "", false
+	}
+	vPath, err := g.getVersionPath(g.program, pkg)
+	if err != nil {
+		return "", false
+	}
+	if mod == pkg {
+		mod = ""
+	}
+	name, ok := g.importNames[g.getPulumiImport(pkg, vPath, mod)]
+	return name, ok
+}
+
 // genPostamble closes the method
 func (g *generator) genPostamble(w io.Writer, nodes []hcl2.Node) {
 
@@ -287,8 +518,23 @@ func (g *generator) genPostamble(w io.Writer, nodes []hcl2.Node) {
 }
 
 func (g *generator) genHelpers(w io.Writer) {
-	for _, v := range g.arrayHelpers {
-		v.generateHelperMethod(w)
+	// Emit the array helpers in a stable order so that the generated program is deterministic.
+	helperTypes := make([]string, 0, len(g.arrayHelpers))
+	for destType := range g.arrayHelpers {
+		helperTypes = append(helperTypes, destType)
+	}
+	sort.Strings(helperTypes)
+	for _, destType := range helperTypes {
+		g.arrayHelpers[destType].generateHelperMethod(w)
+	}
+	if g.needsTryHelper {
+		generateTryHelper(w)
+	}
+	if g.needsCanHelper {
+		generateCanHelper(w)
+	}
+	if g.needsTryHelper || g.needsCanHelper {
+		generateAttemptHelper(w)
 	}
 }
 
@@ -298,14 +544,100 @@ func (g *generator) genNode(w io.Writer, n hcl2.Node) {
 		g.genResource(w, n)
 	case *hcl2.OutputVariable:
 		g.genOutputAssignment(w, n)
-	// TODO
-	// case *hcl2.ConfigVariable:
-	// 	g.genConfigVariable(w, n)
+	case *hcl2.ConfigVariable:
+		g.genConfigVariable(w, n)
 	case *hcl2.LocalVariable:
 		g.genLocalVariable(w, n)
 	}
 }
 
+// nodeDefinition is the syntax of a node's definition, which is either a block or an attribute.
+type nodeDefinition interface {
+	SyntaxNode() hclsyntax.Node
+	GetLeadingTrivia() syntax.TriviaList
+	GetTrailingTrivia() syntax.TriviaList
+}
+
+// definitionOf returns the definition of the given node, if it has one that was parsed from source.
+func definitionOf(n hcl2.Node) (nodeDefinition, bool) {
+	var def nodeDefinition
+	switch n := n.(type) {
+	case *hcl2.Resource:
+		def = n.Definition
+	case *hcl2.OutputVariable:
+		def = n.Definition
+	case *hcl2.ConfigVariable:
+		def = n.Definition
+	case *hcl2.LocalVariable:
+		def = n.Definition
+	default:
+		return nil, false
+	}
+	if def.SyntaxNode() == syntax.None {
+		return nil, false
+	}
+	return def, true
+}
+
+// collectDetachedComments finds the comments that the parser attached to the end of a node's definition but that
+// begin on a later line. The parser treats everything up to the end of the line that follows a block's closing brace
+// as the brace's trailing trivia, so a block comment that precedes the next definition ends up there. Such comments
+// are attributed to the definition that follows them in the source.
+func (g *generator) collectDetachedComments(program *hcl2.Program) {
+	type definedNode struct {
+		node hcl2.Node
+		def  nodeDefinition
+		rng  hcl.Range
+	}
+	var defined []definedNode
+	for _, n := range program.Nodes {
+		if def, ok := definitionOf(n); ok {
+			defined = append(defined, definedNode{node: n, def: def, rng: def.SyntaxNode().Range()})
+		}
+	}
+	sort.SliceStable(defined, func(i, j int) bool {
+		if defined[i].rng.Filename != defined[j].rng.Filename {
+			return defined[i].rng.Filename < defined[j].rng.Filename
+		}
+		return defined[i].rng.Start.Byte < defined[j].rng.Start.Byte
+	})
+
+	g.detachedComments = map[hcl2.Node]syntax.TriviaList{}
+	for i := 1; i < len(defined); i++ {
+		prev, next := defined[i-1], defined[i]
+		if prev.rng.Filename != next.rng.Filename {
+			continue
+		}
+		for _, t := range prev.def.GetTrailingTrivia() {
+			if c, ok := t.(syntax.Comment); ok && c.Range().Start.Line > prev.rng.End.Line {
+				g.detachedComments[next.node] = append(g.detachedComments[next.node], c)
+			}
+		}
+	}
+}
+
+// leadingTrivia returns the trivia that precedes the definition of the given node, including any comments that the
+// parser attached to the end of the preceding definition.
+func (g *generator) leadingTrivia(n hcl2.Node) syntax.TriviaList {
+	trivia := append(syntax.TriviaList(nil), g.detachedComments[n]...)
+	if def, ok := definitionOf(n); ok {
+		trivia = append(trivia, def.GetLeadingTrivia()...)
+	}
+	return trivia
+}
+
+// genLeadingTrivia generates the comments in the given trivia as Go line comments. Block comments are emitted as one
+// line comment per line.
+func (g *generator) genLeadingTrivia(w io.Writer, trivia syntax.TriviaList) {
+	for _, t := range trivia {
+		if c, ok := t.(syntax.Comment); ok {
+			for _, l := range c.Lines {
+				g.Fgenf(w, "//%s\n", strings.TrimRight(l, " \t"))
+			}
+		}
+	}
+}
+
 var resourceType = model.MustNewOpaqueType("pulumi.Resource")
 
 func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Block, []interface{}) {
@@ -315,7 +647,7 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 
 	var block *model.Block
 	var temps []interface{}
-	appendOption := func(name string, value model.Expression, destType model.Type) {
+	appendLoweredOption := func(name string, value model.Expression) {
 		if block == nil {
 			block = &model.Block{
 				Type: "options",
@@ -323,15 +655,17 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 			}
 		}
 
-		value, valueTemps := g.lowerExpression(value, destType, false)
-		temps = append(temps, valueTemps...)
-
 		block.Body.Items = append(block.Body.Items, &model.Attribute{
 			Tokens: syntax.NewAttributeTokens(name),
 			Name:   name,
 			Value:  value,
 		})
 	}
+	appendOption := func(name string, value model.Expression, destType model.Type) {
+		value, valueTemps := g.lowerExpression(value, destType, false)
+		temps = append(temps, valueTemps...)
+		appendLoweredOption(name, value)
+	}
 
 	if opts.Parent != nil {
 		appendOption("Parent", opts.Parent, model.DynamicType)
@@ -348,10 +682,152 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 	if opts.IgnoreChanges != nil {
 		appendOption("IgnoreChanges", opts.IgnoreChanges, model.NewListType(model.StringType))
 	}
+	if opts.DeleteBeforeReplace != nil {
+		appendOption("DeleteBeforeReplace", opts.DeleteBeforeReplace, model.BoolType)
+	}
+	if opts.RetainOnDelete != nil {
+		appendOption("RetainOnDelete", opts.RetainOnDelete, model.BoolType)
+	}
+	if opts.Import != nil {
+		appendOption("Import", opts.Import, model.StringType)
+	}
+	if opts.AdditionalSecretOutputs != nil {
+		appendOption("AdditionalSecretOutputs", opts.AdditionalSecretOutputs, model.NewListType(model.StringType))
+	}
+	if opts.Aliases != nil {
+		if aliases, aliasTemps, ok := g.lowerAliases(opts.Aliases); ok {
+			temps = append(temps, aliasTemps...)
+			appendLoweredOption("Aliases", aliases)
+		}
+	}
+	if opts.CustomTimeouts != nil {
+		if timeouts, timeoutTemps, ok := g.lowerCustomTimeouts(opts.CustomTimeouts); ok {
+			temps = append(temps, timeoutTemps...)
+			appendLoweredOption("Timeouts", timeouts)
+		}
+	}
 
 	return block, temps
 }
 
+// lowerAliases lowers the properties of each alias in the given list so that they can be used as the fields of a
+// pulumi.Alias. The list must be a literal list of literal objects; if it is not, an error is recorded and false is
+// returned.
+func (g *generator) lowerAliases(aliases model.Expression) (*model.TupleConsExpression, []interface{}, bool) {
+	unsupported := func(expr model.Expression) (*model.TupleConsExpression, []interface{}, bool) {
+		g.unsupportedOption(expr, "aliases must be a list of objects")
+		return nil, nil, false
+	}
+
+	tuple, ok := aliases.(*model.TupleConsExpression)
+	if !ok {
+		return unsupported(aliases)
+	}
+
+	// The lowered aliases are built as copies so that the bound program is left untouched.
+	lowered := &model.TupleConsExpression{Syntax: tuple.Syntax, Tokens: tuple.Tokens}
+	var temps []interface{}
+	for _, expr := range tuple.Expressions {
+		alias, ok := expr.(*model.ObjectConsExpression)
+		if !ok {
+			return unsupported(expr)
+		}
+		loweredAlias := &model.ObjectConsExpression{Syntax: alias.Syntax, Tokens: alias.Tokens}
+		for _, item := range alias.Items {
+			key, ok := g.literalKey(item.Key)
+			if !ok {
+				return unsupported(expr)
+			}
+
+			// The parent of an alias is a resource. Its other properties are string inputs.
+			var value model.Expression
+			var valueTemps []interface{}
+			if key == "parent" {
+				value, valueTemps = g.lowerExpression(item.Value, model.DynamicType, false)
+			} else {
+				value, valueTemps = g.lowerExpression(item.Value, model.StringType, true)
+			}
+			temps = append(temps, valueTemps...)
+			loweredAlias.Items = append(loweredAlias.Items, model.ObjectConsItem{Key: item.Key, Value: value})
+		}
+		diags := loweredAlias.Typecheck(false)
+		contract.Assert(len(diags) == 0)
+		lowered.Expressions = append(lowered.Expressions, loweredAlias)
+	}
+	diags := lowered.Typecheck(false)
+	contract.Assert(len(diags) == 0)
+	return lowered, temps, true
+}
+
+// lowerCustomTimeouts lowers the given custom timeouts so that they can be used as the fields of a
+// pulumi.CustomTimeouts. The timeouts must be a literal object; if they are not, an error is recorded and false is
+// returned. False is also returned if no timeouts are set, in which case the option can be omitted.
+func (g *generator) lowerCustomTimeouts(timeouts model.Expression) (*model.ObjectConsExpression, []interface{}, bool) {
+	object, ok := timeouts.(*model.ObjectConsExpression)
+	if !ok {
+		g.unsupportedOption(timeouts, "customTimeouts must be an object")
+		return nil, nil, false
+	}
+	if len(object.Items) == 0 {
+		return nil, nil, false
+	}
+
+	lowered := &model.ObjectConsExpression{Syntax: object.Syntax, Tokens: object.Tokens}
+	var temps []interface{}
+	for _, item := range object.Items {
+		if _, ok := g.literalKey(item.Key); !ok {
+			g.unsupportedOption(timeouts, "customTimeouts must be an object")
+			return nil, nil, false
+		}
+
+		// Timeouts are plain duration strings such as "30m", not inputs.
+		value, valueTemps := g.lowerExpression(item.Value, model.StringType, false)
+		temps = append(temps, valueTemps...)
+		lowered.Items = append(lowered.Items, model.ObjectConsItem{Key: item.Key, Value: value})
+	}
+	diags := lowered.Typecheck(false)
+	contract.Assert(len(diags) == 0)
+	return lowered, temps, true
+}
+
+// unsupportedOption records an error for a resource option whose value cannot be generated.
+func (g *generator) unsupportedOption(expr model.Expression, summary string) {
+	var subject hcl.Range
+	if node := expr.SyntaxNode(); node != nil {
+		subject = node.Range()
+	}
+	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  summary,
+		Subject:  &subject,
+	})
+}
+
+// genObjectFields generates the fields of a Go struct literal from the items of the given lowered object.
+func (g *generator) genObjectFields(w io.Writer, object *model.ObjectConsExpression) {
+	for i, item := range object.Items {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		key, _ := g.literalKey(item.Key)
+		g.Fgenf(w, "%s: %.v", Title(key), item.Value)
+	}
+}
+
+// genAliases generates the argument to pulumi.Aliases for the given lowered list of aliases.
+func (g *generator) genAliases(w io.Writer, aliases *model.TupleConsExpression) {
+	g.Fgen(w, "[]pulumi.Alias{")
+	for i, expr := range aliases.Expressions {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		g.Fgen(w, "{")
+		g.genObjectFields(w, expr.(*model.ObjectConsExpression))
+		g.Fgen(w, "}")
+	}
+	g.Fgen(w, "}")
+}
+
 func (g *generator) genResourceOptions(w io.Writer, block *model.Block) {
 	if block == nil {
 		return
@@ -359,17 +835,105 @@ func (g *generator) genResourceOptions(w io.Writer, block *model.Block) {
 
 	for _, item := range block.Body.Items {
 		attr := item.(*model.Attribute)
+		if aliases, ok := attr.Value.(*model.TupleConsExpression); ok && attr.Name == "Aliases" {
+			g.Fgen(w, ", pulumi.Aliases(")
+			g.genAliases(w, aliases)
+			g.Fgen(w, ")")
+			continue
+		}
+		if attr.Name == "Import" {
+			// The ID of the resource to import is a plain string, but pulumi.Import requires an IDInput.
+			g.Fgenf(w, ", pulumi.Import(pulumi.ID(%v))", attr.Value)
+			continue
+		}
+		if timeouts, ok := attr.Value.(*model.ObjectConsExpression); ok && attr.Name == "Timeouts" {
+			g.Fgen(w, ", pulumi.Timeouts(&pulumi.CustomTimeouts{")
+			g.genObjectFields(w, timeouts)
+			g.Fgen(w, "})")
+			continue
+		}
 		g.Fgenf(w, ", pulumi.%s(%v)", attr.Name, attr.Value)
 	}
 }
 
-func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
+// checkDeprecations records a warning for the given resource if its type or any of its inputs are marked as deprecated
+// by the resource's schema.
+func (g *generator) checkDeprecations(r *hcl2.Resource) {
+	if r.Schema == nil {
+		return
+	}
+
+	if r.Schema.DeprecationMessage != "" {
+		var subject hcl.Range
+		if r.Definition != nil && r.Definition.Syntax != nil {
+			subject = r.Definition.Syntax.DefRange()
+		}
+		g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  fmt.Sprintf("resource type %v is deprecated", r.Token),
+			Detail:   r.Schema.DeprecationMessage,
+			Subject:  &subject,
+		})
+	}
+
+	deprecated := map[string]string{}
+	for _, p := range r.Schema.InputProperties {
+		if p.DeprecationMessage != "" {
+			deprecated[p.Name] = p.DeprecationMessage
+		}
+	}
+	for _, input := range r.Inputs {
+		message, ok := deprecated[input.Name]
+		if !ok {
+			continue
+		}
+		var subject hcl.Range
+		if input.Syntax != nil {
+			subject = input.Syntax.NameRange
+		}
+		g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  fmt.Sprintf("property %v of resource type %v is deprecated", input.Name, r.Token),
+			Detail:   message,
+			Subject:  &subject,
+		})
+	}
+}
+
+// genNestedScope generates the contents of a nested block using the given function. Any err variable declared inside
+// the block is not visible once the block ends, so whether or not err has been declared is restored afterwards.
+func (g *generator) genNestedScope(gen func()) {
+	isErrAssigned := g.isErrAssigned
+	gen()
+	g.isErrAssigned = isErrAssigned
+}
 
-	resName := makeValidIdentifier(r.Name())
+// resourceTypeName returns the package (or its import alias) and the name of the Go type of the given resource.
+func (g *generator) resourceTypeName(r *hcl2.Resource) (string, string) {
 	pkg, mod, typ, _ := r.DecomposeToken()
+	if pkg == "pulumi" && mod == "providers" {
+		// Explicit providers are instantiated via the NewProvider function in the root package of their SDK, e.g.
+		// "pulumi:providers:aws" becomes aws.NewProvider.
+		pkg, mod, typ = typ, "", "Provider"
+	}
 	if mod == "" || strings.HasPrefix(mod, "/") || strings.HasPrefix(mod, "index/") {
 		mod = pkg
 	}
+	return g.getModOrAlias(pkg, mod), typ
+}
+
+func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
+	g.genLeadingTrivia(w, g.leadingTrivia(r))
+
+	if r.Token == hcl2.StackReferenceToken {
+		g.genStackReference(w, r)
+		return
+	}
+
+	g.checkDeprecations(r)
+
+	resName := g.identifier(r.Name())
+	isPackageVar := g.packageVars.Has(r.Name())
 
 	// Compute resource options
 	options, temps := g.lowerResourceOptions(r.Options)
@@ -385,10 +949,12 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 		g.genTemps(w, temps)
 	}
 
-	modOrAlias := g.getModOrAlias(pkg, mod)
+	modOrAlias, typ := g.resourceTypeName(r)
 
 	instantiate := func(varName, resourceName string, w io.Writer) {
-		if g.scopeTraversalRoots.Has(varName) || strings.HasPrefix(varName, "__") {
+		if isPackageVar && varName == resName {
+			g.Fgenf(w, "%s %s.New%s(ctx, %s, ", g.packageVarAssignment(w, varName), modOrAlias, typ, resourceName)
+		} else if g.scopeTraversalRoots.Has(r.Name()) || strings.HasPrefix(varName, "__") {
 			g.Fgenf(w, "%s, err := %s.New%s(ctx, %s, ", varName, modOrAlias, typ, resourceName)
 		} else {
 			assignment := ":="
@@ -399,17 +965,16 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 		}
 		g.isErrAssigned = true
 
+		var genInputs func(w io.Writer)
 		if len(r.Inputs) > 0 {
-			g.Fgenf(w, "&%s.%sArgs{\n", modOrAlias, typ)
-			for _, attr := range r.Inputs {
-				g.Fgenf(w, "%s: ", strings.Title(attr.Name))
-				g.Fgenf(w, "%.v,\n", attr.Value)
-
+			genInputs = func(w io.Writer) {
+				for _, attr := range r.Inputs {
+					g.Fgenf(w, "%s: ", strings.Title(attr.Name))
+					g.Fgenf(w, "%.v,\n", attr.Value)
+				}
 			}
-			g.Fprint(w, "}")
-		} else {
-			g.Fprint(w, "nil")
 		}
+		g.argsStrategy.GenArgs(w, modOrAlias, typ, genInputs)
 		g.genResourceOptions(w, options)
 		g.Fprint(w, ")\n")
 		g.Fgenf(w, "if err != nil {\n")
@@ -422,12 +987,51 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 		rangeExpr, temps := g.lowerExpression(r.Options.Range, rangeType, false)
 		g.genTemps(w, temps)
 
-		g.Fgenf(w, "var %s []*%s.%s\n", resName, modOrAlias, typ)
+		if rangeType == model.BoolType {
+			// A boolean range conditionally creates a single resource.
+			isReferenced := g.scopeTraversalRoots.Has(r.Name())
+			if isReferenced && !isPackageVar {
+				g.Fgenf(w, "var %s *%s.%s\n", resName, modOrAlias, typ)
+			}
+			g.Fgenf(w, "if %.v {\n", rangeExpr)
+			g.genNestedScope(func() {
+				if isReferenced {
+					instantiate("__res", fmt.Sprintf("%q", r.Name()), w)
+					g.Fgenf(w, "%s = __res\n", resName)
+				} else {
+					instantiate(resName, fmt.Sprintf("%q", r.Name()), w)
+				}
+			})
+			g.Fgenf(w, "}\n")
+			return
+		}
+
+		if !isPackageVar {
+			g.Fgenf(w, "var %s []*%s.%s\n", resName, modOrAlias, typ)
+		}
+
+		if rangeType == model.NumberType || rangeType == model.IntType {
+			// A numeric range creates that many resources. The body of the resource cannot refer to the range, so
+			// the index is only used to name each resource.
+			g.genNestedScope(func() {
+				count := "%.v"
+				if _, isLiteral := rangeExpr.(*model.LiteralValueExpression); !isLiteral && rangeType == model.NumberType {
+					count = "int(%.v)"
+				}
+				g.Fgenf(w, "for index0 := 0; index0 < "+count+"; index0++ {\n", rangeExpr)
+				instantiate("__res", fmt.Sprintf(`fmt.Sprintf("%s-%%v", index0)`, g.escapeString(r.Name())), w)
+				g.Fgenf(w, "%s = append(%s, __res)\n", resName, resName)
+				g.Fgenf(w, "}\n")
+			})
+			return
+		}
 
 		// ahead of range statement declaration generate the resource instantiation
 		// to detect and removed unused k,v variables
 		var buf bytes.Buffer
-		instantiate("__res", fmt.Sprintf(`fmt.Sprintf("%s-%%v", key0)`, resName), &buf)
+		g.genNestedScope(func() {
+			instantiate("__res", fmt.Sprintf(`fmt.Sprintf("%s-%%v", key0)`, g.escapeString(r.Name())), &buf)
+		})
 		instantiation := buf.String()
 		isValUsed := strings.Contains(instantiation, "val0")
 		valVar := "_"
@@ -441,16 +1045,74 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 		g.Fgenf(w, "}\n")
 
 	} else {
-		instantiate(resName, fmt.Sprintf("%q", resName), w)
+		instantiate(resName, fmt.Sprintf("%q", r.Name()), w)
 	}
 
 }
 
+// genStackReference generates a call to pulumi.NewStackReference for the given stack reference. The stack reference is
+// named after the stack that it refers to.
+func (g *generator) genStackReference(w io.Writer, r *hcl2.Resource) {
+	if r.Options != nil && r.Options.Range != nil {
+		g.unsupportedOption(r.Options.Range, "stack references cannot be ranged")
+		return
+	}
+
+	options, temps := g.lowerResourceOptions(r.Options)
+	g.genTemps(w, temps)
+
+	var stackName model.Expression
+	for _, input := range r.Inputs {
+		if input.Name == "name" {
+			stackName, temps = g.lowerExpression(input.Value, model.StringType, false)
+			g.genTemps(w, temps)
+		}
+	}
+
+	if g.packageVars.Has(r.Name()) {
+		g.Fgenf(w, "%s pulumi.NewStackReference(ctx, ", g.packageVarAssignment(w, g.identifier(r.Name())))
+	} else if g.scopeTraversalRoots.Has(r.Name()) {
+		g.Fgenf(w, "%s, err := pulumi.NewStackReference(ctx, ", g.identifier(r.Name()))
+	} else {
+		assignment := ":="
+		if g.isErrAssigned {
+			assignment = "="
+		}
+		g.Fgenf(w, "_, err %s pulumi.NewStackReference(ctx, ", assignment)
+	}
+	g.isErrAssigned = true
+
+	if stackName != nil {
+		g.Fgenf(w, "%.v, nil", stackName)
+	} else {
+		g.Fgenf(w, "%q, nil", r.Name())
+	}
+	g.genResourceOptions(w, options)
+	g.Fprint(w, ")\n")
+	g.Fgenf(w, "if err != nil {\n")
+	g.Fgenf(w, "return err\n")
+	g.Fgenf(w, "}\n")
+}
+
 func (g *generator) genOutputAssignment(w io.Writer, v *hcl2.OutputVariable) {
-	isInput := false
+	g.genLeadingTrivia(w, g.leadingTrivia(v))
+
+	// Prompt values must be converted to inputs before they can be exported. Maps other than literals cannot be
+	// converted to their input types directly, so they are converted to outputs instead.
+	containsOutputs, _ := model.ContainsEventuals(v.Value.Type())
+	_, isObjectCons := v.Value.(*model.ObjectConsExpression)
+	toOutput := !containsOutputs && !isObjectCons && isMapCollection(v.Value.Type())
+	isInput := !containsOutputs && !toOutput
 	expr, temps := g.lowerExpression(v.Value, v.Type(), isInput)
 	g.genTemps(w, temps)
-	g.Fgenf(w, "ctx.Export(\"%s\", %.3v)\n", v.Name(), expr)
+	switch {
+	case v.Sensitive:
+		g.Fgenf(w, "ctx.Export(\"%s\", pulumi.ToSecret(%.v))\n", v.Name(), expr)
+	case toOutput:
+		g.Fgenf(w, "ctx.Export(\"%s\", pulumi.ToOutput(%.v))\n", v.Name(), expr)
+	default:
+		g.Fgenf(w, "ctx.Export(\"%s\", %.3v)\n", v.Name(), expr)
+	}
 }
 func (g *generator) genTemps(w io.Writer, temps []interface{}) {
 	singleReturn := ""
@@ -469,9 +1131,8 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 			}
 		}
 		if genZeroValueDecl {
-			// TODO add entropy to var name
-			// currently only used inside anonymous functions (no scope collisions)
-			g.Fgenf(w, "var _zero %s\n", zeroValueType)
+			// This is only used inside anonymous functions, so nested declarations simply shadow one another.
+			g.Fgenf(w, "var %s %s\n", g.zeroValueName, zeroValueType)
 		}
 
 	}
@@ -494,7 +1155,7 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 			g.Fgenf(w, "%.v)\n", args)
 			g.Fgenf(w, "if err != nil {\n")
 			if genZeroValueDecl {
-				g.Fgenf(w, "return _zero, err\n")
+				g.Fgenf(w, "return %s, err\n", g.zeroValueName)
 			} else {
 				g.Fgenf(w, "return err\n")
 			}
@@ -505,7 +1166,7 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 			g.Fgenf(w, "%s, err := ioutil.ReadDir(%.v)\n", t.Name, t.Value.Args[0])
 			g.Fgenf(w, "if err != nil {\n")
 			if genZeroValueDecl {
-				g.Fgenf(w, "return _zero, err\n")
+				g.Fgenf(w, "return %s, err\n", g.zeroValueName)
 			} else {
 				g.Fgenf(w, "return err\n")
 			}
@@ -529,17 +1190,102 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 			g.Fgenf(w, "}\n")
 		case *optionalTemp:
 			g.Fgenf(w, "%s := %.v\n", t.Name, t.Value)
+		case *forTemp:
+			g.genForTemp(w, t)
 		default:
 			contract.Failf("unexpected temp type: %v", t)
 		}
 	}
 }
 
+// isConfigRead returns true if the given config variable must be read by the generated program. A config variable with
+// a default value that the program never references does not need to be read at all.
+func (g *generator) isConfigRead(v *hcl2.ConfigVariable) bool {
+	return v.DefaultValue == nil || g.scopeTraversalRoots.Has(v.Name())
+}
+
+// genConfigVariable generates code that reads the given config variable. Required variables are read with the
+// appropriately-typed cfg.Require* method. Variables with a default value are initialized with that default, which is
+// then replaced by the configured value if one is present.
+func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
+	if !g.isConfigRead(v) {
+		return
+	}
+
+	if !g.configCreated {
+		g.Fgenf(w, "%s := config.New(ctx, \"\")\n", g.configName)
+		g.configCreated = true
+	}
+
+	getType, isScalar := "Object", false
+	switch v.Type() {
+	case model.StringType:
+		getType, isScalar = "", true
+	case model.NumberType:
+		getType, isScalar = "Float64", true
+	case model.IntType:
+		getType, isScalar = "Int", true
+	case model.BoolType:
+		getType, isScalar = "Bool", true
+	}
+
+	name, typeName := g.identifier(v.Name()), g.argumentTypeName(nil, v.Type(), false)
+	isReferenced := g.scopeTraversalRoots.Has(v.Name())
+
+	// Variables that are shared between the functions of a split program have already been declared.
+	declare := ":="
+	if g.packageVars.Has(v.Name()) {
+		declare = "="
+	}
+
+	if v.DefaultValue == nil {
+		switch {
+		case isScalar && isReferenced:
+			g.Fgenf(w, "%s %s %s.Require%s(%q)\n", name, declare, g.configName, getType, v.Name())
+		case isScalar:
+			g.Fgenf(w, "_ = %s.Require%s(%q)\n", g.configName, getType, v.Name())
+		case isReferenced:
+			if declare == ":=" {
+				g.Fgenf(w, "var %s %s\n", name, typeName)
+			}
+			g.Fgenf(w, "%s.RequireObject(%q, &%s)\n", g.configName, v.Name(), name)
+		default:
+			g.Fgenf(w, "%s.RequireObject(%q, new(%s))\n", g.configName, v.Name(), typeName)
+		}
+		return
+	}
+
+	defaultValue, temps := g.lowerExpression(v.DefaultValue, v.Type(), false)
+	g.genTemps(w, temps)
+	if v.Type() == model.NumberType {
+		// Untyped numeric constants default to int, so make sure that the variable has the type returned by GetFloat64.
+		g.Fgenf(w, "%s %s float64(%.v)\n", name, declare, defaultValue)
+	} else {
+		g.Fgenf(w, "%s %s %.v\n", name, declare, defaultValue)
+	}
+
+	if !isScalar {
+		g.Fgenf(w, "if err := %s.GetObject(%q, &%s); err != nil {\n", g.configName, v.Name(), name)
+		g.Fgenf(w, "return err\n")
+		g.Fgenf(w, "}\n")
+		return
+	}
+
+	// The cfg.Get* methods return the zero value of their type if the variable is not set, which would prevent a zero
+	// value from overriding the default. The cfg.Try* methods instead return an error if the variable is not set.
+	param := g.configParamName
+	g.Fgenf(w, "if %s, err := %s.Try%s(%q); err == nil {\n", param, g.configName, getType, v.Name())
+	g.Fgenf(w, "%s = %s\n", name, param)
+	g.Fgenf(w, "}\n")
+}
+
 func (g *generator) genLocalVariable(w io.Writer, v *hcl2.LocalVariable) {
+	g.genLeadingTrivia(w, g.leadingTrivia(v))
+
 	isInput := false
 	expr, temps := g.lowerExpression(v.Definition.Value, v.Type(), isInput)
 	g.genTemps(w, temps)
-	name := makeValidIdentifier(v.Name())
+	name := g.identifier(v.Name())
 	assignment := ":="
 	if !g.scopeTraversalRoots.Has(v.Name()) {
 		name = "_"
@@ -556,6 +1302,8 @@ func (g *generator) genLocalVariable(w io.Writer, v *hcl2.LocalVariable) {
 			g.Fgenf(w, "if err != nil {\n")
 			g.Fgenf(w, "return err\n")
 			g.Fgenf(w, "}\n")
+		default:
+			g.Fgenf(w, "%s := %.3v;\n", name, expr)
 		}
 	default:
 		g.Fgenf(w, "%s := %.3v;\n", name, expr)
@@ -582,18 +1330,20 @@ func (g *generator) useLookupInvokeForm(token string) bool {
 	if len(modSplit) >= 2 {
 		fn = Title(modSplit[1])
 	}
-	fnLookup := "Lookup" + fn[3:]
-	pkgContext := g.contexts[pkg][mod]
-	if pkgContext.names.has(fnLookup) {
-		return true
+	if !strings.HasPrefix(fn, "Get") {
+		return false
 	}
-
-	return false
+	fnLookup := "Lookup" + fn[3:]
+	pkgContext, ok := g.contexts[pkg][mod]
+	return ok && pkgContext.names.has(fnLookup)
 }
 
 // getModOrAlias attempts to reconstruct the import statement and check if the imported package
 // is aliased, returning that alias if available.
 func (g *generator) getModOrAlias(pkg, mod string) string {
+	if name, ok := g.renamedImport(pkg, mod); ok {
+		return name
+	}
 	if mods, ok := g.contexts[pkg]; ok {
 		if ctx, ok := mods[mod]; ok {
 			imp := fmt.Sprintf("%s/%s", ctx.importBasePath, ctx.modToPkg[mod])
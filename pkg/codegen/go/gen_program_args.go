@@ -0,0 +1,47 @@
+package gen
+
+import (
+	"fmt"
+	"io"
+)
+
+// ArgsStrategy controls how the arguments passed to a resource's constructor are built. Different generations of
+// provider SDKs expose their resource args types in different shapes, so the strategy allows generated programs to
+// target whichever shape the SDK in use expects.
+type ArgsStrategy interface {
+	// GenArgs writes an expression that constructs the args for the resource type typ defined in the package
+	// modOrAlias. genInputs writes the resource's input properties as a sequence of `Name: value,` lines, and is nil if
+	// the resource has no inputs.
+	GenArgs(w io.Writer, modOrAlias, typ string, genInputs func(w io.Writer))
+}
+
+// PointerArgs is the default ArgsStrategy. It constructs args as a pointer to a composite literal, e.g.
+// `&s3.BucketArgs{...}`, and passes a typed nil, e.g. `(*s3.BucketArgs)(nil)`, for resources without inputs so that
+// the call is never ambiguous.
+type PointerArgs struct{}
+
+// GenArgs implements ArgsStrategy.
+func (PointerArgs) GenArgs(w io.Writer, modOrAlias, typ string, genInputs func(w io.Writer)) {
+	if genInputs == nil {
+		fmt.Fprintf(w, "(*%s.%sArgs)(nil)", modOrAlias, typ)
+		return
+	}
+	fmt.Fprintf(w, "&%s.%sArgs{\n", modOrAlias, typ)
+	genInputs(w)
+	fmt.Fprint(w, "}")
+}
+
+// ValueArgs is an ArgsStrategy for SDKs whose constructors accept args by value. It constructs args as a plain
+// composite literal, e.g. `s3.BucketArgs{...}`, including for resources without inputs.
+type ValueArgs struct{}
+
+// GenArgs implements ArgsStrategy.
+func (ValueArgs) GenArgs(w io.Writer, modOrAlias, typ string, genInputs func(w io.Writer)) {
+	if genInputs == nil {
+		fmt.Fprintf(w, "%s.%sArgs{}", modOrAlias, typ)
+		return
+	}
+	fmt.Fprintf(w, "%s.%sArgs{\n", modOrAlias, typ)
+	genInputs(w)
+	fmt.Fprint(w, "}")
+}
@@ -0,0 +1,243 @@
+package gen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// sdkPath is the path to the Pulumi SDK in this repository. Generated programs are built against it.
+var sdkPath = filepath.Join("..", "..", "..", "sdk")
+
+// providerSDKs lists the providers whose SDKs are generated from the test schemas in order to build programs that use
+// them.
+var providerSDKs = []string{"aws", "random"}
+
+// largeProviderSDKs lists the providers whose SDKs take long enough to build that programs that use them are not built
+// during short test runs.
+var largeProviderSDKs = codegen.NewStringSet("aws")
+
+// buildProgram builds the given generated program.
+func buildProgram(t *testing.T, files map[string][]byte) {
+	runGo(t, writeProgramModule(t, files), "build", "./...")
+}
+
+// writeProgramModule writes the given generated program to a temporary Go module that uses the SDK in this repository
+// and returns the module's directory. The test is skipped if the go tool is not available.
+func writeProgramModule(t *testing.T, files map[string][]byte) string {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go tool is not available")
+	}
+
+	dir, err := ioutil.TempDir("", "gen-program")
+	if err != nil {
+		t.Fatalf("could not create program directory: %v", err)
+	}
+	t.Cleanup(func() { contract.IgnoreError(os.RemoveAll(dir)) })
+
+	for name, contents := range files {
+		writeProgramFile(t, filepath.Join(dir, name), contents)
+	}
+
+	sdk, err := filepath.Abs(sdkPath)
+	if err != nil {
+		t.Fatalf("could not find the SDK: %v", err)
+	}
+	goMod := string(files["go.mod"])
+	goMod += fmt.Sprintf("\nreplace github.com/pulumi/pulumi/sdk/v2 => %s\n", sdk)
+	for _, path := range requiredModules(goMod) {
+		for _, name := range providerSDKs {
+			if strings.HasPrefix(path, fmt.Sprintf("github.com/pulumi/pulumi-%s/sdk", name)) {
+				if largeProviderSDKs.Has(name) && testing.Short() {
+					t.Skip("Skipped in short test run")
+				}
+				writeProviderSDK(t, filepath.Join(dir, name+"-sdk"), name, path, sdk)
+				goMod += fmt.Sprintf("replace %s => ./%s-sdk\n", path, name)
+			}
+		}
+	}
+	writeProgramFile(t, filepath.Join(dir, "go.mod"), []byte(goMod))
+
+	goSum, err := ioutil.ReadFile(filepath.Join(sdk, "go.sum"))
+	if err != nil {
+		t.Fatalf("could not read the SDK's go.sum: %v", err)
+	}
+	writeProgramFile(t, filepath.Join(dir, "go.sum"), goSum)
+	return dir
+}
+
+// runProgram builds the given generated program and runs it against mock resource monitors. It returns the values
+// of the program's stack outputs, formatted with %v.
+func runProgram(t *testing.T, files map[string][]byte) map[string]string {
+	// Run the program's main function from a test in its package that replaces pulumi.Run with a version that uses
+	// mocks and records the program's exports.
+	mainGo := string(files["main.go"])
+	mainGo = strings.Replace(mainGo, "pulumi.Run(", "runWithMocks(", 1)
+	mainGo = strings.Replace(mainGo, "ctx.Export(", "exportForTest(ctx, ", -1)
+
+	runFiles := map[string][]byte{}
+	for name, contents := range files {
+		runFiles[name] = contents
+	}
+	runFiles["main.go"] = []byte(mainGo)
+	// A package in a module named "main" cannot be tested, so the module is renamed.
+	runFiles["go.mod"] = bytes.Replace(files["go.mod"], []byte("module main\n"), []byte("module program\n"), 1)
+	runFiles["main_test.go"] = []byte(programHarness)
+
+	out := runGo(t, writeProgramModule(t, runFiles), "test", "-count=1", "-v", "-run", "TestProgram", ".")
+
+	outputs := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "export ") {
+			kvp := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
+			outputs[kvp[0]] = kvp[1]
+		}
+	}
+	return outputs
+}
+
+// programHarness runs a generated program against mock resource monitors and prints its stack outputs.
+const programHarness = `package main
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+type mocks int
+
+func (mocks) NewResource(typeToken, name string, inputs resource.PropertyMap, provider, id string) (string,
+	resource.PropertyMap, error) {
+	return name + "-id", inputs, nil
+}
+
+func (mocks) Call(token string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+	return args, nil
+}
+
+var runErr error
+var exports = map[string]pulumi.Input{}
+
+func runWithMocks(body pulumi.RunFunc) {
+	runErr = pulumi.RunErr(body, pulumi.WithMocks("project", "stack", mocks(0)))
+}
+
+func exportForTest(ctx *pulumi.Context, name string, value pulumi.Input) {
+	exports[name] = value
+	ctx.Export(name, value)
+}
+
+func TestProgram(t *testing.T) {
+	main()
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+
+	var names []string
+	for name := range exports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := make(chan interface{}, 1)
+		pulumi.ToOutput(exports[name]).ApplyT(func(v interface{}) interface{} {
+			values <- v
+			return v
+		})
+		select {
+		case v := <-values:
+			fmt.Printf("export %s=%v\n", name, v)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for %s", name)
+		}
+	}
+}
+`
+
+// requiredModules returns the paths of the modules required by the given go.mod.
+func requiredModules(goMod string) []string {
+	var paths []string
+	inRequire := false
+	for _, line := range strings.Split(goMod, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "require (":
+			inRequire = true
+		case line == ")":
+			inRequire = false
+		case inRequire && line != "":
+			paths = append(paths, strings.Fields(line)[0])
+		case strings.HasPrefix(line, "require "):
+			paths = append(paths, strings.Fields(line)[1])
+		}
+	}
+	return paths
+}
+
+// writeProviderSDK generates the Go SDK for the named provider from its test schema into the module at dir.
+func writeProviderSDK(t *testing.T, dir, name, modulePath, sdk string) {
+	contents, err := ioutil.ReadFile(filepath.Join(testdataPath, name+".json"))
+	if err != nil {
+		t.Fatalf("could not read schema for %s: %v", name, err)
+	}
+	var spec schema.PackageSpec
+	if err = json.Unmarshal(contents, &spec); err != nil {
+		t.Fatalf("could not parse schema for %s: %v", name, err)
+	}
+	pkg, err := schema.ImportSpec(spec, nil)
+	if err != nil {
+		t.Fatalf("could not import schema for %s: %v", name, err)
+	}
+	files, err := GeneratePackage("test", pkg)
+	if err != nil {
+		t.Fatalf("could not generate SDK for %s: %v", name, err)
+	}
+	for path, contents := range files {
+		writeProgramFile(t, filepath.Join(dir, "go", path), contents)
+	}
+
+	goMod := fmt.Sprintf("module %s\n\ngo 1.14\n\nrequire github.com/pulumi/pulumi/sdk/v2 v2.0.0\n\n"+
+		"replace github.com/pulumi/pulumi/sdk/v2 => %s\n", modulePath, sdk)
+	writeProgramFile(t, filepath.Join(dir, "go.mod"), []byte(goMod))
+}
+
+func writeProgramFile(t *testing.T, path string, contents []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatalf("could not create directory for %s: %v", path, err)
+	}
+	if err := ioutil.WriteFile(path, contents, 0600); err != nil {
+		t.Fatalf("could not write %s: %v", path, err)
+	}
+}
+
+// runGo runs the go tool in the given directory without network access and returns its output. Paths are trimmed
+// from the build so that packages built for one test are cached for the next, although each is written to a new
+// directory.
+func runGo(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod -trimpath", "GOPROXY=off", "GOSUMDB=off")
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("go %s failed: %v\n%s", strings.Join(args, " "), err, out.String())
+	}
+	return out.String()
+}
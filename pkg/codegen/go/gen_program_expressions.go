@@ -137,8 +137,20 @@ func (g *generator) GenConditionalExpression(w io.Writer, expr *model.Conditiona
 	contract.Failf("unlowered conditional expression @ %v", expr.SyntaxNode().Range())
 }
 
-// GenForExpression generates code for a ForExpression.
-func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) { /*TODO*/ }
+// GenForExpression rejects the for expression. For expressions are normally lowered into loops by rewriteFor; those
+// that are left in place depend on eventual values, which cannot be ranged over directly.
+func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) {
+	var subject hcl.Range
+	if node := expr.SyntaxNode(); node != nil {
+		subject = node.Range()
+	}
+	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "for expressions that depend on outputs are not supported",
+		Subject:  &subject,
+	})
+	g.Fgen(w, "nil")
+}
 
 func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionCallExpression) {
 	switch expr.Name {
@@ -146,6 +158,9 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		isInput := true
 		// bypass passthrough __convert expressions that might require prefix: "pulumi.*"
 		if c, ok := expr.Args[0].(*model.FunctionCallExpression); ok && c.Name == hcl2.IntrinsicConvert {
+			if arg, ok := c.Args[0].(*model.ScopeTraversalExpression); ok && g.genStackReferenceOutput(w, arg, c.Type()) {
+				return
+			}
 			switch c := c.Args[0].(type) {
 			case *model.RelativeTraversalExpression, *model.ScopeTraversalExpression:
 				expr.Args[0] = c
@@ -166,6 +181,9 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 			g.Fgenf(w, ")")
 		}
 	case hcl2.IntrinsicConvert:
+		if arg, ok := expr.Args[0].(*model.ScopeTraversalExpression); ok && g.genStackReferenceOutput(w, arg, expr.Type()) {
+			return
+		}
 		switch arg := expr.Args[0].(type) {
 		case *model.TupleConsExpression:
 			g.genTupleConsExpression(w, arg, expr.Type())
@@ -205,7 +223,7 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		if module == "" {
 			module = pkg
 		}
-		name := fmt.Sprintf("%s.%s", module, fn)
+		name := fmt.Sprintf("%s.%s", g.getModOrAlias(pkg, module), fn)
 
 		optionsBag := ""
 		var buf bytes.Buffer
@@ -242,6 +260,31 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		// g.Fgenf(w, "%.20v.Split(%v)", expr.Args[1], expr.Args[0])
 	case "toJSON":
 		contract.Failf("unlowered toJSON function expression @ %v", expr.SyntaxNode().Range())
+	case "try":
+		// Each argument is wrapped in a closure so that the helper can fall back to the next argument if evaluating
+		// the current one panics. Every closure returns a value of the same type so that the result can be asserted
+		// to that type regardless of which argument produced it.
+		g.needsTryHelper = true
+		typeName := g.argumentTypeName(expr, expr.Type(), false)
+		if _, isOutput := expr.Type().(*model.OutputType); isOutput {
+			typeName += "Output"
+		}
+		g.Fgen(w, "try(")
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgen(w, "func() interface{} { return ")
+			g.genTryArgument(w, arg, expr.Type(), typeName)
+			g.Fgen(w, " }")
+		}
+		g.Fgen(w, ")")
+		if typeName != "interface{}" {
+			g.Fgenf(w, ".(%s)", typeName)
+		}
+	case "can":
+		g.needsCanHelper = true
+		g.Fgenf(w, "can(func() interface{} { return %.v })", expr.Args[0])
 	case "mimeType":
 		g.Fgenf(w, "mime.TypeByExtension(path.Ext(%.v))", expr.Args[0])
 	default:
@@ -249,6 +292,39 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 	}
 }
 
+// genTryArgument generates an argument to try, converted to the Go type named by typeName.
+func (g *generator) genTryArgument(w io.Writer, arg model.Expression, destType model.Type, typeName string) {
+	if _, isOutput := destType.(*model.OutputType); isOutput {
+		// Outputs are converted using their To*Output methods. Prompt arguments are first converted to inputs.
+		if _, isOutput = arg.Type().(*model.OutputType); isOutput {
+			g.Fgenf(w, "%.v.To%s()", arg, strings.TrimPrefix(typeName, "pulumi."))
+			return
+		}
+		inputTypeName := strings.TrimSuffix(typeName, "Output")
+		if inputTypeName == "pulumi.Any" {
+			g.Fgenf(w, "pulumi.Any(%.v)", arg)
+			return
+		}
+		g.Fgenf(w, "%s(%.v).To%s()", inputTypeName, arg, strings.TrimPrefix(typeName, "pulumi."))
+		return
+	}
+
+	switch argTypeName := g.argumentTypeName(arg, arg.Type(), false); {
+	case typeName == "interface{}":
+		g.Fgenf(w, "%.v", arg)
+	case argTypeName == "interface{}":
+		// A dynamically-typed argument that does not hold a value of the right type falls through to the next one.
+		g.Fgenf(w, "%.v.(%s)", arg, typeName)
+	default:
+		// Literals are converted explicitly so that e.g. an integral number is not returned as an int.
+		if _, isLiteral := arg.(*model.LiteralValueExpression); isLiteral || argTypeName != typeName {
+			g.Fgenf(w, "%s(%.v)", typeName, arg)
+			return
+		}
+		g.Fgenf(w, "%.v", arg)
+	}
+}
+
 func (g *generator) GenIndexExpression(w io.Writer, expr *model.IndexExpression) {
 	g.Fgenf(w, "%.20v[%.v]", expr.Collection, expr.Key)
 }
@@ -426,6 +502,10 @@ func (g *generator) genScopeTraversalExpression(w io.Writer, expr *model.ScopeTr
 	genIDCall := false
 
 	if resource, ok := expr.Parts[0].(*hcl2.Resource); ok {
+		if g.genStackReferenceOutput(w, expr, model.DynamicType) {
+			return
+		}
+
 		isInput = false
 		if _, ok := hcl2.GetSchemaForType(resource.InputType); ok {
 			// convert .id into .ID()
@@ -473,7 +553,11 @@ func (g *generator) genScopeTraversalExpression(w io.Writer, expr *model.ScopeTr
 			contract.Failf("unexpected traversal on range expression: %s", part)
 		}
 	} else {
-		g.Fgen(w, makeValidIdentifier(rootName))
+		if _, ok := expr.Parts[0].(hcl2.Node); ok {
+			g.Fgen(w, g.identifier(rootName))
+		} else {
+			g.Fgen(w, makeValidIdentifier(rootName))
+		}
 		isRootResource := false
 		g.genRelativeTraversal(w, expr.Traversal.SimpleSplit().Rel, expr.Parts[1:], isRootResource)
 	}
@@ -487,6 +571,106 @@ func (g *generator) genScopeTraversalExpression(w io.Writer, expr *model.ScopeTr
 	}
 }
 
+// stackReferenceOutputName returns the name of the output accessed by a traversal that accesses a single output of a
+// stack reference, e.g. `ref.outputs["vpcId"]`. If the traversal accesses anything else, false is returned.
+func stackReferenceOutputName(expr *model.ScopeTraversalExpression) (string, bool) {
+	if resource, ok := expr.Parts[0].(*hcl2.Resource); !ok || resource.Token != hcl2.StackReferenceToken {
+		return "", false
+	}
+	if len(expr.Traversal) != 3 {
+		return "", false
+	}
+	if attr, ok := expr.Traversal[1].(hcl.TraverseAttr); !ok || attr.Name != "outputs" {
+		return "", false
+	}
+
+	switch part := expr.Traversal[2].(type) {
+	case hcl.TraverseAttr:
+		return part.Name, true
+	case hcl.TraverseIndex:
+		if part.Key.Type() != cty.String {
+			return "", false
+		}
+		return part.Key.AsString(), true
+	default:
+		return "", false
+	}
+}
+
+// rewriteStackReferenceOutput wraps a stack reference output that is assigned directly to a destination of a primitive
+// type in a conversion to an output of that type. The conversion carries the destination type through the rest of
+// lowering so that genStackReferenceOutput can generate a typed output rather than an AnyOutput.
+func rewriteStackReferenceOutput(expr model.Expression, destType model.Type) model.Expression {
+	traversal, ok := expr.(*model.ScopeTraversalExpression)
+	if !ok {
+		return expr
+	}
+	if _, ok := stackReferenceOutputName(traversal); !ok {
+		return expr
+	}
+
+	switch elementType := outputElementType(destType); elementType {
+	case model.StringType, model.NumberType, model.IntType, model.BoolType:
+		return hcl2.NewConvertCall(expr, model.NewOutputType(elementType))
+	default:
+		return expr
+	}
+}
+
+// outputElementType returns the type of the values that may be assigned to a destination of the given type once any
+// outputs and nones have been removed.
+func outputElementType(destType model.Type) model.Type {
+	destType = model.ResolveOutputs(destType)
+	if union, ok := destType.(*model.UnionType); ok {
+		var elementTypes []model.Type
+		for _, t := range union.ElementTypes {
+			if t != model.NoneType {
+				elementTypes = append(elementTypes, t)
+			}
+		}
+		if len(elementTypes) == 1 {
+			return elementTypes[0]
+		}
+	}
+	return destType
+}
+
+// genStackReferenceOutput generates a call that reads a single output of a stack reference, e.g.
+// `ref.outputs["vpcId"]`. The call is chosen by the type of the destination: a string destination uses
+// GetStringOutput, other primitive destinations convert the result of GetOutput with a typed apply, and anything else
+// receives the AnyOutput returned by GetOutput. If the traversal accesses anything else, nothing is generated and false
+// is returned.
+func (g *generator) genStackReferenceOutput(w io.Writer, expr *model.ScopeTraversalExpression,
+	destType model.Type) bool {
+
+	name, ok := stackReferenceOutputName(expr)
+	if !ok {
+		return false
+	}
+
+	ref := g.identifier(expr.RootName)
+	switch outputElementType(destType) {
+	case model.StringType:
+		g.Fgenf(w, "%s.GetStringOutput(pulumi.String(%q))", ref, name)
+	case model.NumberType:
+		g.Fgenf(w, "%s.GetOutput(pulumi.String(%q)).ApplyT(func(v interface{}) float64 {\n", ref, name)
+		g.Fgenf(w, "return v.(float64)\n")
+		g.Fgenf(w, "}).(pulumi.Float64Output)")
+	case model.IntType:
+		// Stack outputs are decoded from JSON, so integral outputs are represented as float64 values.
+		g.Fgenf(w, "%s.GetOutput(pulumi.String(%q)).ApplyT(func(v interface{}) int {\n", ref, name)
+		g.Fgenf(w, "return int(v.(float64))\n")
+		g.Fgenf(w, "}).(pulumi.IntOutput)")
+	case model.BoolType:
+		g.Fgenf(w, "%s.GetOutput(pulumi.String(%q)).ApplyT(func(v interface{}) bool {\n", ref, name)
+		g.Fgenf(w, "return v.(bool)\n")
+		g.Fgenf(w, "}).(pulumi.BoolOutput)")
+	default:
+		g.Fgenf(w, "%s.GetOutput(pulumi.String(%q))", ref, name)
+	}
+	return true
+}
+
 // GenSplatExpression generates code for a SplatExpression.
 func (g *generator) GenSplatExpression(w io.Writer, expr *model.SplatExpression) {
 	contract.Failf("unlowered splat expression @ %v", expr.SyntaxNode().Range())
@@ -659,7 +843,7 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 				}
 				elmType = valType
 			}
-			if allSameType && elmType != "" {
+			if allSameType && elmType != "" && elmType != "pulumi.Any" {
 				return fmt.Sprintf("%sMap", elmType)
 			}
 			return "pulumi.Map"
@@ -668,11 +852,19 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 	case *model.MapType:
 		valType := g.argumentTypeName(nil, destType.ElementType, isInput)
 		if isInput {
-			return fmt.Sprintf("pulumi.%sMap", Title(valType))
+			// There is no pulumi.AnyMap: maps of dynamically-typed values are represented as pulumi.Map.
+			if strings.HasPrefix(valType, "pulumi.") && valType != "pulumi.Any" {
+				return fmt.Sprintf("%sMap", valType)
+			}
+			return "pulumi.Map"
 		}
 		return fmt.Sprintf("map[string]%s", valType)
 	case *model.ListType:
 		argTypeName := g.argumentTypeName(nil, destType.ElementType, isInput)
+		if argTypeName == "pulumi.Any" {
+			// There is no pulumi.AnyArray: lists of dynamically-typed values are represented as pulumi.Array.
+			return "pulumi.Array"
+		}
 		if strings.HasPrefix(argTypeName, "pulumi.") && argTypeName != "pulumi.Resource" {
 			return fmt.Sprintf("%sArray", argTypeName)
 		}
@@ -694,6 +886,9 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 
 		if elmType != nil {
 			argTypeName := g.argumentTypeName(nil, elmType, isInput)
+			if argTypeName == "pulumi.Any" {
+				return "pulumi.Array"
+			}
 			if strings.HasPrefix(argTypeName, "pulumi.") && argTypeName != "pulumi.Resource" {
 				return fmt.Sprintf("%sArray", argTypeName)
 			}
@@ -708,9 +903,33 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 		isInput = true
 		return g.argumentTypeName(expr, destType.ElementType, isInput)
 	case *model.UnionType:
+		var opaqueTypes []model.Type
 		for _, ut := range destType.ElementTypes {
 			if _, isOpaqueType := ut.(*model.OpaqueType); isOpaqueType {
-				return g.argumentTypeName(expr, ut, isInput)
+				isNew := true
+				for _, t := range opaqueTypes {
+					isNew = isNew && !t.Equals(ut)
+				}
+				if isNew {
+					opaqueTypes = append(opaqueTypes, ut)
+				}
+			}
+		}
+		switch len(opaqueTypes) {
+		case 0:
+			// Input types are unions of a type and its output type. If resolving outputs leaves a single type, use it.
+			if resolved := model.ResolveOutputs(destType); resolved != model.Type(destType) {
+				if _, isUnion := resolved.(*model.UnionType); !isUnion {
+					return g.argumentTypeName(expr, resolved, isInput)
+				}
+			}
+		case 1:
+			return g.argumentTypeName(expr, opaqueTypes[0], isInput)
+		default:
+			// Values of heterogeneous types (e.g. the elements of a tuple that contains both strings and numbers) must
+			// be dynamically typed.
+			if isInput {
+				return "pulumi.Any"
 			}
 		}
 		return "interface{}"
@@ -773,7 +992,9 @@ func (g *generator) lowerExpression(expr model.Expression, typ model.Type, isInp
 	model.Expression, []interface{}) {
 	expr = hcl2.RewritePropertyReferences(expr)
 	expr, diags := hcl2.RewriteApplies(expr, nameInfo(0), false /*TODO*/)
+	expr = rewriteStackReferenceOutput(expr, typ)
 	expr = hcl2.RewriteConversions(expr, typ)
+	expr, fTemps, forDiags := g.rewriteFor(expr, g.forSpiller)
 	expr, tTemps, ternDiags := g.rewriteTernaries(expr, g.ternaryTempSpiller)
 	expr, jTemps, jsonDiags := g.rewriteToJSON(expr, g.jsonTempSpiller)
 	expr, rTemps, readDirDiags := g.rewriteReadDir(expr, g.readDirTempSpiller)
@@ -784,6 +1005,9 @@ func (g *generator) lowerExpression(expr model.Expression, typ model.Type, isInp
 		expr = rewriteInputs(expr)
 	}
 	var temps []interface{}
+	for _, t := range fTemps {
+		temps = append(temps, t)
+	}
 	for _, t := range tTemps {
 		temps = append(temps, t)
 	}
@@ -799,6 +1023,7 @@ func (g *generator) lowerExpression(expr model.Expression, typ model.Type, isInp
 	for _, t := range oTemps {
 		temps = append(temps, t)
 	}
+	diags = append(diags, forDiags...)
 	diags = append(diags, ternDiags...)
 	diags = append(diags, jsonDiags...)
 	diags = append(diags, readDirDiags...)
@@ -826,7 +1051,12 @@ func (g *generator) genApply(w io.Writer, expr *model.FunctionCallExpression) {
 	retType := g.argumentTypeName(nil, then.Signature.ReturnType, isInput)
 	// TODO account for outputs in other namespaces like aws
 	typeAssertion := fmt.Sprintf(".(%sOutput)", retType)
-	if !strings.HasPrefix(retType, "pulumi.") {
+	switch {
+	case strings.HasPrefix(retType, "[]") || strings.HasPrefix(retType, "map["):
+		// Lists and maps are asserted to the output type that corresponds to their input type, e.g.
+		// pulumi.StringArrayOutput for a []string.
+		typeAssertion = fmt.Sprintf(".(%sOutput)", g.argumentTypeName(nil, then.Signature.ReturnType, true))
+	case !strings.HasPrefix(retType, "pulumi."):
 		typeAssertion = fmt.Sprintf(".(pulumi.%sOutput)", Title(retType))
 	}
 
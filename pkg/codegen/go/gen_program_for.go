@@ -0,0 +1,200 @@
+package gen
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+type forTemp struct {
+	Name  string
+	Value *model.ForExpression
+}
+
+func (ft *forTemp) Type() model.Type {
+	return ft.Value.Type()
+}
+
+func (ft *forTemp) Traverse(traverser hcl.Traverser) (model.Traversable, hcl.Diagnostics) {
+	return ft.Type().Traverse(traverser)
+}
+
+func (ft *forTemp) SyntaxNode() hclsyntax.Node {
+	return syntax.None
+}
+
+type forSpiller struct {
+	temps      []*forTemp
+	count      int
+	applyDepth int
+}
+
+func (fs *forSpiller) spillExpression(x model.Expression) (model.Expression, hcl.Diagnostics) {
+	var temp *forTemp
+	switch x := x.(type) {
+	case *model.FunctionCallExpression:
+		if x.Name == hcl2.IntrinsicApply {
+			fs.applyDepth++
+		}
+		return x, nil
+	case *model.ForExpression:
+		// A for expression inside an apply refers to the apply's parameters, so it cannot be spilled out of the
+		// apply; it is lowered into a loop inside the apply's callback instead. A for expression over an eventual
+		// collection outside of an apply cannot range over the collection directly, so it is left in place and
+		// rejected by GenForExpression.
+		if fs.applyDepth > 0 || isEventual(x.Collection.Type()) {
+			return x, nil
+		}
+		temp = &forTemp{
+			Name:  fmt.Sprintf("for%d", fs.count),
+			Value: x,
+		}
+		fs.temps = append(fs.temps, temp)
+		fs.count++
+	default:
+		return x, nil
+	}
+	return &model.ScopeTraversalExpression{
+		RootName:  temp.Name,
+		Traversal: hcl.Traversal{hcl.TraverseRoot{Name: ""}},
+		Parts:     []model.Traversable{temp},
+	}, nil
+}
+
+func (fs *forSpiller) leaveExpression(x model.Expression) (model.Expression, hcl.Diagnostics) {
+	if call, ok := x.(*model.FunctionCallExpression); ok && call.Name == hcl2.IntrinsicApply {
+		fs.applyDepth--
+	}
+	return x, nil
+}
+
+// isEventual returns true if the given type is or contains an output or promise.
+func isEventual(t model.Type) bool {
+	return model.ResolveOutputs(t) != t
+}
+
+// isMapCollection returns true if ranging over a value of the given type yields string keys.
+func isMapCollection(t model.Type) bool {
+	switch model.ResolveOutputs(t).(type) {
+	case *model.MapType, *model.ObjectType:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewriteFor spills for expressions into temps. The spill happens on the way down the tree so that the key, value,
+// and condition of each for expression are left untouched: these are lowered separately inside the generated loop, as
+// they may refer to the loop variables.
+func (g *generator) rewriteFor(
+	x model.Expression,
+	spiller *forSpiller,
+) (model.Expression, []*forTemp, hcl.Diagnostics) {
+	spiller.temps, spiller.applyDepth = nil, 0
+	x, diags := model.VisitExpression(x, spiller.spillExpression, spiller.leaveExpression)
+
+	return x, spiller.temps, diags
+}
+
+// genForTemp generates a loop that builds the list or map produced by a for expression.
+func (g *generator) genForTemp(w io.Writer, t *forTemp) {
+	isInput := false
+	expr := t.Value
+
+	collection, collectionTemps := g.lowerExpression(expr.Collection, expr.Collection.Type(), isInput)
+	g.genTemps(w, collectionTemps)
+
+	valueType := g.argumentTypeName(expr.Value, expr.Value.Type(), isInput)
+	if expr.Key == nil {
+		g.Fgenf(w, "var %s []%s\n", t.Name, valueType)
+	} else {
+		if expr.Group {
+			valueType = "[]" + valueType
+		}
+		g.Fgenf(w, "%s := make(map[string]%s)\n", t.Name, valueType)
+	}
+
+	keyVar, valueVar := "_", "_"
+	if expr.KeyVariable != nil && g.forVariableIsUsed(expr, expr.KeyVariable) {
+		keyVar = makeValidIdentifier(expr.KeyVariable.Name)
+	}
+	if g.forVariableIsUsed(expr, expr.ValueVariable) {
+		valueVar = makeValidIdentifier(expr.ValueVariable.Name)
+	}
+	if isMapCollection(expr.Collection.Type()) {
+		// Go randomizes the order in which maps are ranged over, so range over the sorted keys instead in order to
+		// produce the same results each time the program is run.
+		collectionVar, keysVar := t.Name+"Collection", t.Name+"Keys"
+		g.Fgenf(w, "%s := %.v\n", collectionVar, collection)
+		g.Fgenf(w, "%s := make([]string, 0, len(%s))\n", keysVar, collectionVar)
+		g.Fgenf(w, "for k := range %s {\n", collectionVar)
+		g.Fgenf(w, "%s = append(%s, k)\n", keysVar, keysVar)
+		g.Fgenf(w, "}\n")
+		g.Fgenf(w, "sort.Strings(%s)\n", keysVar)
+		if keyVar == "_" && valueVar != "_" {
+			keyVar = t.Name + "Key"
+		}
+		g.Fgenf(w, "for _, %s := range %s {\n", keyVar, keysVar)
+		if valueVar != "_" {
+			g.Fgenf(w, "%s := %s[%s]\n", valueVar, collectionVar, keyVar)
+		}
+	} else if valueVar == "_" {
+		if keyVar == "_" {
+			g.Fgenf(w, "for range %.v {\n", collection)
+		} else {
+			g.Fgenf(w, "for %s := range %.v {\n", keyVar, collection)
+		}
+	} else {
+		g.Fgenf(w, "for %s, %s := range %.v {\n", keyVar, valueVar, collection)
+	}
+	if keyVar != "_" && !isMapCollection(expr.Collection.Type()) {
+		// The indices of lists are numbers, which are represented as float64s rather than ints.
+		g.Fgenf(w, "%s := float64(%s)\n", keyVar, keyVar)
+	}
+
+	if expr.Condition != nil {
+		condition, conditionTemps := g.lowerExpression(expr.Condition, model.BoolType, isInput)
+		g.genTemps(w, conditionTemps)
+		g.Fgenf(w, "if !(%.v) {\n", condition)
+		g.Fgenf(w, "continue\n")
+		g.Fgenf(w, "}\n")
+	}
+
+	value, valueTemps := g.lowerExpression(expr.Value, expr.Value.Type(), isInput)
+	g.genTemps(w, valueTemps)
+	if expr.Key == nil {
+		g.Fgenf(w, "%s = append(%s, %.v)\n", t.Name, t.Name, value)
+	} else {
+		key, keyTemps := g.lowerExpression(expr.Key, model.StringType, isInput)
+		g.genTemps(w, keyTemps)
+		if expr.Group {
+			g.Fgenf(w, "%s[%.v] = append(%s[%.v], %.v)\n", t.Name, key, t.Name, key, value)
+		} else {
+			g.Fgenf(w, "%s[%.v] = %.v\n", t.Name, key, value)
+		}
+	}
+	g.Fgenf(w, "}\n")
+}
+
+// forVariableIsUsed returns true if the given loop variable is referenced by the key, value, or condition of the for
+// expression. Go rejects unused loop variables, so unreferenced variables must be replaced with the blank identifier.
+func (g *generator) forVariableIsUsed(expr *model.ForExpression, v *model.Variable) bool {
+	used := false
+	visitor := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		if x, ok := x.(*model.ScopeTraversalExpression); ok && len(x.Parts) > 0 && x.Parts[0] == v {
+			used = true
+		}
+		return x, nil
+	}
+	for _, x := range []model.Expression{expr.Key, expr.Value, expr.Condition} {
+		_, diags := model.VisitExpression(x, model.IdentityVisitor, visitor)
+		contract.Assert(len(diags) == 0)
+	}
+	return used
+}
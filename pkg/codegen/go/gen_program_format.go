@@ -0,0 +1,252 @@
+package gen
+
+import (
+	"bytes"
+	"go/ast"
+	gofmt "go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minWrappedLiteralLength is the minimum number of characters placed in each piece of a wrapped string literal.
+const minWrappedLiteralLength = 16
+
+// formatSource applies the formatting pass described by opts to the given gofmt'd source. If no options are enabled,
+// the source is returned untouched.
+func formatSource(source []byte, opts GenerateProgramOptions) ([]byte, error) {
+	var err error
+	if opts.GroupImports {
+		if source, err = groupImports(source); err != nil {
+			return nil, err
+		}
+	}
+	if opts.MaxLineWidth > 0 {
+		if source, err = wrapStringLiterals(source, opts.MaxLineWidth); err != nil {
+			return nil, err
+		}
+	}
+	return source, nil
+}
+
+// groupImports rewrites the import declarations of the given source as a single declaration that contains a
+// standard library group followed by a third-party group.
+func groupImports(source []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", source, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	var decls []*ast.GenDecl
+	for _, d := range f.Decls {
+		if d, ok := d.(*ast.GenDecl); ok && d.Tok == token.IMPORT {
+			decls = append(decls, d)
+		}
+	}
+	if len(decls) == 0 {
+		return source, nil
+	}
+
+	var std, thirdParty []string
+	for _, spec := range f.Imports {
+		imp := spec.Path.Value
+		if spec.Name != nil {
+			imp = spec.Name.Name + " " + imp
+		}
+
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			thirdParty = append(thirdParty, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	byPath := func(imps []string) func(i, j int) bool {
+		return func(i, j int) bool {
+			return importPath(imps[i]) < importPath(imps[j])
+		}
+	}
+	sort.Slice(std, byPath(std))
+	sort.Slice(thirdParty, byPath(thirdParty))
+
+	var imports bytes.Buffer
+	imports.WriteString("import (\n")
+	for _, imp := range std {
+		imports.WriteString(imp + "\n")
+	}
+	if len(std) > 0 && len(thirdParty) > 0 {
+		imports.WriteString("\n")
+	}
+	for _, imp := range thirdParty {
+		imports.WriteString(imp + "\n")
+	}
+	imports.WriteString(")")
+
+	// Replace the first import declaration with the grouped imports and remove the rest.
+	var buf bytes.Buffer
+	last := 0
+	for i, d := range decls {
+		start, end := fset.Position(d.Pos()).Offset, fset.Position(d.End()).Offset
+		buf.Write(source[last:start])
+		if i == 0 {
+			buf.Write(imports.Bytes())
+		}
+		last = end
+	}
+	buf.Write(source[last:])
+
+	return gofmt.Source(buf.Bytes())
+}
+
+// importPath returns the quoted path of an import spec that may be preceded by a name.
+func importPath(imp string) string {
+	return imp[strings.Index(imp, `"`):]
+}
+
+// wrapStringLiterals splits interpreted string literals on lines that exceed the given width into concatenations of
+// shorter literals. Literals are only split after whitespace, so a literal without whitespace is left as it is.
+func wrapStringLiterals(source []byte, width int) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", source, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(source, []byte("\n"))
+
+	type edit struct {
+		start, end  int
+		replacement string
+	}
+	var edits []edit
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ImportSpec:
+			// Import paths must be single literals.
+			return false
+		case *ast.Field:
+			// As must struct tags.
+			return false
+		case *ast.IndexExpr:
+			// Indexing a concatenation would change the meaning of the expression, so skip literals that are indexed.
+			if _, ok := n.X.(*ast.BasicLit); ok {
+				ast.Inspect(n.Index, visit)
+				return false
+			}
+		case *ast.SliceExpr:
+			// As is slicing one.
+			if _, ok := n.X.(*ast.BasicLit); ok {
+				for _, index := range []ast.Expr{n.Low, n.High, n.Max} {
+					if index != nil {
+						ast.Inspect(index, visit)
+					}
+				}
+				return false
+			}
+		case *ast.BasicLit:
+			if n.Kind != token.STRING || !strings.HasPrefix(n.Value, `"`) {
+				return false
+			}
+
+			pos := fset.Position(n.Pos())
+			if len(lines[pos.Line-1]) <= width {
+				return false
+			}
+
+			value, err := strconv.Unquote(n.Value)
+			if err != nil {
+				return false
+			}
+
+			chunkLength := width - pos.Column - len(`"" +`)
+			if chunkLength < minWrappedLiteralLength {
+				chunkLength = minWrappedLiteralLength
+			}
+			pieces := splitAfterWhitespace(value, chunkLength)
+			if len(pieces) < 2 {
+				return false
+			}
+			for i, piece := range pieces {
+				pieces[i] = strconv.Quote(piece)
+			}
+			edits = append(edits, edit{
+				start:       pos.Offset,
+				end:         fset.Position(n.End()).Offset,
+				replacement: strings.Join(pieces, " +\n"),
+			})
+			return false
+		}
+		return true
+	}
+	ast.Inspect(f, visit)
+	if len(edits) == 0 {
+		return source, nil
+	}
+
+	// ast.Inspect visits literals in source order, so the edits can be applied in a single forward pass.
+	var buf bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		buf.Write(source[last:e.start])
+		buf.WriteString(e.replacement)
+		last = e.end
+	}
+	buf.Write(source[last:])
+
+	return gofmt.Source(buf.Bytes())
+}
+
+// splitAfterWhitespace splits the given string into pieces of at most max characters, breaking only after runs of
+// whitespace. A word that is longer than max is placed in a piece of its own. Characters are counted by decoding the
+// string as UTF-8, so a multi-byte character is never split, and each byte that is not valid UTF-8 counts as a single
+// character and is kept intact.
+func splitAfterWhitespace(value string, max int) []string {
+	// Break the string into words, each followed by the whitespace that ends it.
+	type word struct {
+		text   string
+		length int
+	}
+	var words []word
+	start, length, inSpace := 0, 0, false
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		space := unicode.IsSpace(r)
+		if inSpace && !space {
+			words = append(words, word{text: value[start:i], length: length})
+			start, length = i, 0
+		}
+		inSpace = space
+		length++
+		i += size
+	}
+	if start < len(value) {
+		words = append(words, word{text: value[start:], length: length})
+	}
+
+	// Then fill each piece with as many words as fit.
+	var pieces []string
+	var piece strings.Builder
+	pieceLength := 0
+	for _, w := range words {
+		if pieceLength > 0 && pieceLength+w.length > max {
+			pieces = append(pieces, piece.String())
+			piece.Reset()
+			pieceLength = 0
+		}
+		piece.WriteString(w.text)
+		pieceLength += w.length
+	}
+	if pieceLength > 0 {
+		pieces = append(pieces, piece.String())
+	}
+	return pieces
+}
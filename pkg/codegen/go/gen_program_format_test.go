@@ -0,0 +1,117 @@
+package gen
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const unformattedProgram = `package main
+
+import (
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"fmt"
+	s3 "github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
+)
+
+func main() {
+	fmt.Println(s3.NewBucket, pulumi.Run)
+}
+`
+
+func TestFormatSourceGroupImports(t *testing.T) {
+	expected := `package main
+
+import (
+	"fmt"
+
+	s3 "github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+func main() {
+	fmt.Println(s3.NewBucket, pulumi.Run)
+}
+`
+
+	actual, err := formatSource([]byte(unformattedProgram), GenerateProgramOptions{GroupImports: true})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestFormatSourceWrapStringLiterals(t *testing.T) {
+	source := `package main
+
+func main() {
+	message := "the quick brown fox jumps over the lazy dog"
+	_ = message
+}
+`
+	expected := `package main
+
+func main() {
+	message := "the quick brown fox " +
+		"jumps over the lazy dog"
+	_ = message
+}
+`
+
+	actual, err := formatSource([]byte(source), GenerateProgramOptions{MaxLineWidth: 40})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestFormatSourceWrapStringLiteralsSkipsSlicedLiterals(t *testing.T) {
+	source := `package main
+
+func main() {
+	word := "the quick brown fox jumps over the lazy dog"[4:9]
+	_ = word
+}
+`
+
+	actual, err := formatSource([]byte(source), GenerateProgramOptions{MaxLineWidth: 40})
+	assert.NoError(t, err)
+	assert.Equal(t, source, string(actual))
+}
+
+func TestFormatSourceWrapStringLiteralsPreservesEscapes(t *testing.T) {
+	// The literal contains bytes that are not valid UTF-8, which must survive wrapping unchanged.
+	const value = "caf\xe9 au lait \xff\xfe and \u00e9clair for everyone"
+	source := `package main
+
+func main() {
+	message := "caf\xe9 au lait \xff\xfe and \u00e9clair for everyone"
+	_ = message
+}
+`
+	expected := `package main
+
+func main() {
+	message := "caf\xe9 au lait \xff\xfe and " +
+		"éclair for everyone"
+	_ = message
+}
+`
+
+	actual, err := formatSource([]byte(source), GenerateProgramOptions{MaxLineWidth: 40})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+
+	var wrapped strings.Builder
+	for _, piece := range regexp.MustCompile(`"[^"]*"`).FindAllString(string(actual), -1) {
+		unquoted, err := strconv.Unquote(piece)
+		assert.NoError(t, err)
+		wrapped.WriteString(unquoted)
+	}
+	assert.Equal(t, value, wrapped.String())
+}
+
+func TestFormatSourceDisabled(t *testing.T) {
+	actual, err := formatSource([]byte(unformattedProgram), GenerateProgramOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, unformattedProgram, string(actual))
+}
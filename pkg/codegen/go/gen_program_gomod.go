@@ -0,0 +1,65 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/version"
+)
+
+// defaultModulePath is the module path declared by a generated go.mod if no other path is given.
+const defaultModulePath = "main"
+
+// minimumSDKVersion is the version of the Pulumi SDK that generated programs require if the version of this build is
+// not known.
+const minimumSDKVersion = "v2.0.0"
+
+// genGoMod generates a go.mod for the given program that requires the Pulumi SDK and the SDK of each provider package
+// used by the program, pinned to the version of the package's schema.
+func (g *generator) genGoMod(program *hcl2.Program, modulePath string) ([]byte, error) {
+	if modulePath == "" {
+		modulePath = defaultModulePath
+	}
+
+	requires := map[string]string{
+		"github.com/pulumi/pulumi/sdk/v2": sdkVersion(),
+	}
+	for _, pkg := range program.Packages() {
+		if pkg.Version == nil {
+			return nil, errors.Errorf("could not find package version information for pkg: %s", pkg.Name)
+		}
+		vPath, err := g.getVersionPath(program, pkg.Name)
+		if err != nil {
+			return nil, err
+		}
+		requires[fmt.Sprintf("github.com/pulumi/pulumi-%s/sdk%s", pkg.Name, vPath)] = "v" + pkg.Version.String()
+	}
+
+	paths := make([]string, 0, len(requires))
+	for path := range requires {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "module %s\n\ngo 1.14\n\nrequire (\n", modulePath)
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "\t%s %s\n", path, requires[path])
+	}
+	fmt.Fprintf(&buf, ")\n")
+	return buf.Bytes(), nil
+}
+
+// sdkVersion returns the version of the Pulumi SDK that generated programs require: the version of this build if it
+// is a release of the v2 SDK, or minimumSDKVersion otherwise.
+func sdkVersion() string {
+	v, err := semver.ParseTolerant(version.Version)
+	if err != nil || v.Major != 2 || len(v.Pre) != 0 || len(v.Build) != 0 {
+		return minimumSDKVersion
+	}
+	return "v" + v.String()
+}
@@ -0,0 +1,90 @@
+package gen
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// genProject generates a Pulumi.yaml for the given program that declares each of the program's config variables in
+// its template section, so that `pulumi new` prompts for them. The description of each variable is taken from the
+// comments that precede it, followed by its type; the description of a variable without comments is just its type. If
+// the program has no config variables, nil is returned.
+func (g *generator) genProject(program *hcl2.Program, modulePath string) ([]byte, error) {
+	config := map[string]workspace.ProjectTemplateConfigValue{}
+	for _, n := range program.Nodes {
+		v, ok := n.(*hcl2.ConfigVariable)
+		if !ok {
+			continue
+		}
+
+		description := v.Type().String()
+		if comment := triviaComment(g.leadingTrivia(v)); comment != "" {
+			description = comment + " (" + description + ")"
+		}
+		defaultValue, _ := configDefault(v.DefaultValue)
+
+		config[v.Name()] = workspace.ProjectTemplateConfigValue{
+			Description: description,
+			Default:     defaultValue,
+			Secret:      v.Sensitive,
+		}
+	}
+	if len(config) == 0 {
+		return nil, nil
+	}
+
+	if modulePath == "" {
+		modulePath = defaultModulePath
+	}
+	project := &workspace.Project{
+		Name:     tokens.PackageName(path.Base(modulePath)),
+		Runtime:  workspace.NewProjectRuntimeInfo("go", nil),
+		Template: &workspace.ProjectTemplate{Config: config},
+	}
+	return encoding.YAML.Marshal(project)
+}
+
+// triviaComment returns the text of the comments in the given trivia joined into a single line.
+func triviaComment(trivia syntax.TriviaList) string {
+	var words []string
+	for _, t := range trivia {
+		if c, ok := t.(syntax.Comment); ok {
+			for _, l := range c.Lines {
+				words = append(words, strings.Fields(l)...)
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// configDefault returns the default value of a config variable as it is written in stack configuration. Only literal
+// defaults can be represented; for any other default, false is returned.
+func configDefault(expr model.Expression) (string, bool) {
+	if template, ok := expr.(*model.TemplateExpression); ok && len(template.Parts) == 1 {
+		expr = template.Parts[0]
+	}
+	lit, ok := expr.(*model.LiteralValueExpression)
+	if !ok || lit.Value.IsNull() {
+		return "", false
+	}
+
+	switch lit.Value.Type() {
+	case cty.String:
+		return lit.Value.AsString(), true
+	case cty.Number:
+		return lit.Value.AsBigFloat().Text('f', -1), true
+	case cty.Bool:
+		return strconv.FormatBool(lit.Value.True()), true
+	default:
+		return "", false
+	}
+}
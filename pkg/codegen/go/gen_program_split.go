@@ -0,0 +1,232 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// programPartition is a run of consecutive resources from the same provider package. When a program is split into
+// files, each partition is generated as a function in the file for its package.
+type programPartition struct {
+	pkg   string
+	fn    string
+	nodes []hcl2.Node
+}
+
+// partitionPackage returns the provider package whose file the given node is generated in when a program is split, or
+// the empty string if the node is generated in main.go.
+func partitionPackage(n hcl2.Node) string {
+	r, ok := n.(*hcl2.Resource)
+	if !ok || r.Token == hcl2.StackReferenceToken {
+		return ""
+	}
+	pkg, mod, name, _ := r.DecomposeToken()
+	if pkg == "pulumi" && mod == "providers" {
+		return name
+	}
+	return pkg
+}
+
+// genSplitProgram generates the given program as a file for each provider package and a main.go. The resources of
+// each package are generated in functions in the package's file, which main.go calls in dependency order; all other
+// nodes are generated in main.go. Variables that are used by more than one function are declared at package scope.
+//
+// Local variables are not shared between functions, as their types are not always known. If a program's local
+// variables are used by resources from more than one package, or if the program has no resources, nil is returned and
+// the program should be generated as a single file.
+func (g *generator) genSplitProgram(program *hcl2.Program, nodes []hcl2.Node) map[string][]byte {
+	// Function names must not collide with any of the program's identifiers, which may be referenced from main.
+	taken := codegen.NewStringSet()
+	for _, id := range g.identifiers {
+		taken.Add(id)
+	}
+
+	// Partition the nodes. A node that is generated in main.go is its own step.
+	var steps []interface{}
+	var packages []string
+	var mainNodes []hcl2.Node
+	packageNodes := map[string][]hcl2.Node{}
+	owners := map[hcl2.Node]*programPartition{}
+	var current *programPartition
+	for _, n := range nodes {
+		pkg := partitionPackage(n)
+		if pkg == "" {
+			current = nil
+			steps = append(steps, n)
+			mainNodes = append(mainNodes, n)
+			owners[n] = nil
+			continue
+		}
+
+		if current == nil || current.pkg != pkg {
+			base := "create" + Title(makeValidIdentifier(pkg))
+			fn := base
+			for i := 2; taken.Has(fn); i++ {
+				fn = fmt.Sprintf("%s%d", base, i)
+			}
+			taken.Add(fn)
+
+			current = &programPartition{pkg: pkg, fn: fn}
+			steps = append(steps, current)
+			if _, has := packageNodes[pkg]; !has {
+				packages = append(packages, pkg)
+			}
+		}
+		current.nodes = append(current.nodes, n)
+		packageNodes[pkg] = append(packageNodes[pkg], n)
+		owners[n] = current
+	}
+	if len(packages) == 0 {
+		return nil
+	}
+
+	// Find the variables that are used outside of the function that defines them.
+	packageVars := codegen.NewStringSet()
+	var sharedLocal hcl2.Node
+	for _, n := range nodes {
+		diags := n.VisitExpressions(nil, func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+			if traversal, ok := x.(*model.ScopeTraversalExpression); ok {
+				def, ok := traversal.Parts[0].(hcl2.Node)
+				if !ok {
+					return x, nil
+				}
+				if owner, has := owners[def]; has && owner != owners[n] {
+					if _, isLocal := def.(*hcl2.LocalVariable); isLocal {
+						sharedLocal = def
+					}
+					packageVars.Add(def.Name())
+				}
+			}
+			return x, nil
+		})
+		contract.Assert(len(diags) == 0)
+	}
+	if sharedLocal != nil {
+		g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary: fmt.Sprintf("the program was generated as a single file because the local variable %v is used by "+
+				"resources from more than one package", sharedLocal.Name()),
+		})
+		return nil
+	}
+	g.packageVars = packageVars
+
+	// As in GenerateProgram, imports are collected both before and after the nodes are lowered.
+	mainStdImports, mainPulumiImports := g.collectNodeImports(program, mainNodes, codegen.NewStringSet(),
+		codegen.NewStringSet())
+	stdImports, pulumiImports := map[string]codegen.StringSet{}, map[string]codegen.StringSet{}
+	for _, pkg := range packages {
+		stdImports[pkg], pulumiImports[pkg] = g.collectNodeImports(program, packageNodes[pkg], codegen.NewStringSet(),
+			codegen.NewStringSet())
+	}
+
+	var mainBody bytes.Buffer
+	functions := map[string]*bytes.Buffer{}
+	for _, step := range steps {
+		switch step := step.(type) {
+		case hcl2.Node:
+			g.genNode(&mainBody, step)
+		case *programPartition:
+			g.Fgenf(&mainBody, "if err := %s(ctx); err != nil {\n", step.fn)
+			g.Fgenf(&mainBody, "return err\n")
+			g.Fgenf(&mainBody, "}\n")
+
+			if functions[step.pkg] == nil {
+				functions[step.pkg] = &bytes.Buffer{}
+			}
+			g.genPartition(functions[step.pkg], step)
+		}
+	}
+	g.genPostamble(&mainBody, mainNodes)
+
+	files := map[string][]byte{}
+	for _, pkg := range packages {
+		var file bytes.Buffer
+		g.collectNodeImports(program, packageNodes[pkg], stdImports[pkg], pulumiImports[pkg])
+		g.genFileHeader(&file, stdImports[pkg], pulumiImports[pkg])
+		g.genPackageVars(&file, packageNodes[pkg])
+		file.Write(functions[pkg].Bytes())
+		files[pkg+".go"] = file.Bytes()
+	}
+
+	var main bytes.Buffer
+	g.collectNodeImports(program, mainNodes, mainStdImports, mainPulumiImports)
+	g.genFileHeader(&main, mainStdImports, mainPulumiImports)
+	g.genPackageVars(&main, mainNodes)
+	g.Fprintf(&main, "func main() {\n")
+	g.Fprintf(&main, "pulumi.Run(func(ctx *pulumi.Context) error {\n")
+	main.Write(mainBody.Bytes())
+	files["main.go"] = main.Bytes()
+
+	return files
+}
+
+// genPartition generates the function for the given partition of a split program.
+func (g *generator) genPartition(w io.Writer, p *programPartition) {
+	// The function has its own err variable.
+	isErrAssigned := g.isErrAssigned
+	g.isErrAssigned = false
+
+	g.Fgenf(w, "func %s(ctx *pulumi.Context) error {\n", p.fn)
+	for _, n := range p.nodes {
+		g.genNode(w, n)
+	}
+	g.Fgenf(w, "return nil\n")
+	g.Fgenf(w, "}\n")
+
+	g.isErrAssigned = isErrAssigned
+}
+
+// genPackageVars declares the package variables that hold the values of those of the given nodes that are shared
+// between the functions of a split program.
+func (g *generator) genPackageVars(w io.Writer, nodes []hcl2.Node) {
+	var vars []hcl2.Node
+	for _, n := range nodes {
+		if g.packageVars.Has(n.Name()) {
+			vars = append(vars, n)
+		}
+	}
+	if len(vars) == 0 {
+		return
+	}
+
+	g.Fgenf(w, "var (\n")
+	for _, n := range vars {
+		var typ string
+		switch n := n.(type) {
+		case *hcl2.Resource:
+			if n.Token == hcl2.StackReferenceToken {
+				typ = "*pulumi.StackReference"
+				break
+			}
+			modOrAlias, name := g.resourceTypeName(n)
+			typ = fmt.Sprintf("*%s.%s", modOrAlias, name)
+			if n.Options != nil && n.Options.Range != nil && model.ResolveOutputs(n.Options.Range.Type()) != model.BoolType {
+				typ = "[]" + typ
+			}
+		case *hcl2.ConfigVariable:
+			typ = g.argumentTypeName(nil, n.Type(), false)
+		default:
+			contract.Failf("unexpected package variable %v", n.Name())
+		}
+		g.Fgenf(w, "%s %s\n", g.identifier(n.Name()), typ)
+	}
+	g.Fgenf(w, ")\n")
+}
+
+// packageVarAssignment returns the left-hand side of an assignment to the given package variable and err. If err has
+// not yet been declared, its declaration is generated first.
+func (g *generator) packageVarAssignment(w io.Writer, name string) string {
+	if !g.isErrAssigned {
+		g.Fgenf(w, "var err error\n")
+		g.isErrAssigned = true
+	}
+	return fmt.Sprintf("%s, err =", name)
+}
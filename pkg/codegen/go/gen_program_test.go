@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
@@ -66,6 +68,793 @@ func TestGenProgram(t *testing.T) {
 	}
 }
 
+func TestGenResourceIdentifierCollision(t *testing.T) {
+	source := `resource my-res "aws:s3:Bucket" {}
+resource my_res "aws:s3:Bucket" {}
+
+output first {
+	value = my-res.bucket
+}
+output second {
+	value = my_res.bucket
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `my_res, err := s3.NewBucket(ctx, "my-res", (*s3.BucketArgs)(nil))`)
+	assert.Contains(t, main, `my_res2, err := s3.NewBucket(ctx, "my_res", (*s3.BucketArgs)(nil))`)
+	assert.Contains(t, main, `ctx.Export("first", my_res.Bucket)`)
+	assert.Contains(t, main, `ctx.Export("second", my_res2.Bucket)`)
+}
+
+func TestGenResourceNamePreserved(t *testing.T) {
+	source := `resource my-bucket "aws:s3:Bucket" {}
+resource my-replica "aws:s3:Bucket" {
+	options {
+		range = 2
+	}
+}
+
+output bucketName {
+	value = my-bucket.bucket
+}
+`
+	files, diags := generateProgramFromSource(t, source)
+	assert.Len(t, diags, 0)
+	main := string(files["main.go"])
+	// Only the Go variable is sanitized; the logical name of the resource is passed through unchanged.
+	assert.Contains(t, main, `my_bucket, err := s3.NewBucket(ctx, "my-bucket", (*s3.BucketArgs)(nil))`)
+	assert.Contains(t, main, `ctx.Export("bucketName", my_bucket.Bucket)`)
+	assert.Contains(t, main, `var my_replica []*s3.Bucket`)
+	assert.Contains(t, main, `s3.NewBucket(ctx, fmt.Sprintf("my-replica-%v", index0), (*s3.BucketArgs)(nil))`)
+}
+
+func TestGenDeprecationWarnings(t *testing.T) {
+	source := `resource server "aws:ec2:Instance" {
+	ami = "ami-0123456789"
+	instanceType = "t2.micro"
+	securityGroups = ["default"]
+}
+`
+	_, diags := generateProgramFromSource(t, source)
+	assert.False(t, diags.HasErrors())
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, hcl.DiagWarning, diags[0].Severity)
+		assert.Equal(t, "property securityGroups of resource type aws:ec2:Instance is deprecated", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "Use of `securityGroups` is discouraged")
+
+		// The diagnostic points at the name of the deprecated property. The parser numbers lines from zero.
+		assert.Equal(t, 3, diags[0].Subject.Start.Line)
+		assert.Equal(t, 2, diags[0].Subject.Start.Column)
+	}
+
+	source = `resource server "aws:ec2:Instance" {
+	ami = "ami-0123456789"
+	instanceType = "t2.micro"
+}
+`
+	_, diags = generateProgramFromSource(t, source)
+	assert.Len(t, diags, 0)
+}
+
+func TestGenExplicitProvider(t *testing.T) {
+	source := `resource provider "pulumi:providers:aws" {
+	region = "us-west-2"
+}
+
+resource bucket "aws:s3:Bucket" {
+	options {
+		provider = provider
+	}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `"github.com/pulumi/pulumi-aws/sdk/v2/go/aws"`)
+	assert.NotContains(t, main, `"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/providers"`)
+	assert.Contains(t, main, `provider, err := aws.NewProvider(ctx, "provider", &aws.ProviderArgs{`)
+	assert.Contains(t, main, `Region: pulumi.String("us-west-2"),`)
+	assert.Contains(t, main, `s3.NewBucket(ctx, "bucket", (*s3.BucketArgs)(nil), pulumi.Provider(provider))`)
+
+	buildProgram(t, files)
+}
+
+func TestGenTypedNilArgs(t *testing.T) {
+	source := `resource pet "random:index/randomPet:RandomPet" {
+	options {
+		protect = true
+	}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// A resource without inputs passes a typed nil for its args, followed by its options.
+	assert.Contains(t, main, `random.NewRandomPet(ctx, "pet", (*random.RandomPetArgs)(nil), pulumi.Protect(true))`)
+	buildProgram(t, files)
+}
+
+func TestGenConfigVariables(t *testing.T) {
+	// The local named `cfg` forces the config object to be renamed.
+	source := `cfg = "cfg"
+config bucketName "string" {}
+config prefix "string" {
+	default = "logs"
+}
+config retentionDays "int" {
+	default = 7
+}
+config versioned "bool" {}
+config unused "number" {
+	default = 1.5
+}
+resource bucket "aws:s3:Bucket" {
+	bucket = "${bucketName}-${cfg}"
+	versioning = {
+		enabled = versioned
+	}
+	lifecycleRules = [{
+		enabled = true
+		prefix = prefix
+		expiration = {
+			days = retentionDays
+		}
+	}]
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"`)
+	assert.Equal(t, 1, strings.Count(main, `cfg2 := config.New(ctx, "")`))
+	assert.Contains(t, main, `bucketName := cfg2.Require("bucketName")`)
+	assert.Contains(t, main, `versioned := cfg2.RequireBool("versioned")`)
+	assert.Contains(t, main, `prefix := "logs"
+		if param, err := cfg2.Try("prefix"); err == nil {
+			prefix = param
+		}`)
+	assert.Contains(t, main, `retentionDays := 7
+		if param, err := cfg2.TryInt("retentionDays"); err == nil {
+			retentionDays = param
+		}`)
+	assert.NotContains(t, main, `"unused"`)
+}
+
+func TestGenConfigVariableZeroOverride(t *testing.T) {
+	source := `config versioned "bool" {
+	default = true
+}
+resource bucket "aws:s3:Bucket" {
+	versioning = {
+		enabled = versioned
+	}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	// A configured value of false must be able to override the default of true, so the variable is read with TryBool
+	// rather than compared against the zero value returned by GetBool.
+	assert.Contains(t, main, `versioned := true
+		if param, err := cfg.TryBool("versioned"); err == nil {
+			versioned = param
+		}`)
+	assert.NotContains(t, main, "GetBool")
+}
+
+func TestGenGoMod(t *testing.T) {
+	source := `resource bucket "aws:s3:Bucket" {}
+resource pet "random:index/randomPet:RandomPet" {}
+`
+	files, _ := generateProgramFromSource(t, source)
+	assert.Equal(t, `module main
+
+go 1.14
+
+require (
+	github.com/pulumi/pulumi-aws/sdk/v2 v2.10.0
+	github.com/pulumi/pulumi-random/sdk/v2 v2.2.0
+	github.com/pulumi/pulumi/sdk/v2 v2.0.0
+)
+`, string(files["go.mod"]))
+
+	pathFiles, _ := generateProgramFromSourceWithOptions(t, source,
+		GenerateProgramOptions{ModulePath: "example.com/program"})
+	assert.True(t, strings.HasPrefix(string(pathFiles["go.mod"]), "module example.com/program\n"))
+
+	// The generated go.mod is sufficient to build the program. This is checked last, as it is skipped in short test
+	// runs.
+	buildProgram(t, files)
+}
+
+func TestGenAliases(t *testing.T) {
+	source := `resource parent "aws:s3:Bucket" {}
+resource bucket "aws:s3:Bucket" {
+	options {
+		aliases = [{ name = "oldBucket" }, { parent = parent }]
+	}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `pulumi.Aliases([]pulumi.Alias{{Name: pulumi.String("oldBucket")}, {Parent: parent}})`)
+}
+
+func TestGenCustomTimeouts(t *testing.T) {
+	source := `resource bucket "aws:s3:Bucket" {
+	options {
+		customTimeouts = { create = "30m", delete = "1h" }
+	}
+}
+resource logs "aws:s3:Bucket" {
+	options {
+		customTimeouts = {}
+	}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `pulumi.Timeouts(&pulumi.CustomTimeouts{Create: "30m", Delete: "1h"})`)
+	assert.Contains(t, main, `s3.NewBucket(ctx, "logs", (*s3.BucketArgs)(nil))`)
+}
+
+func TestGenImport(t *testing.T) {
+	source := `resource bucket "aws:s3:Bucket" {
+	options {
+		protect = true
+		ignoreChanges = [tags]
+		import = "my-bucket-1234"
+	}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `pulumi.Protect(true), pulumi.IgnoreChanges([]string{`)
+	assert.Contains(t, main, `}), pulumi.Import(pulumi.ID("my-bucket-1234")))`)
+}
+
+func TestGenSensitiveOutputs(t *testing.T) {
+	source := `resource bucket "aws:s3:Bucket" {}
+output bucketName {
+	value = bucket.id
+}
+output bucketArn {
+	value = bucket.arn
+	sensitive = true
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `ctx.Export("bucketName", bucket.ID())`)
+	assert.Contains(t, main, `ctx.Export("bucketArn", pulumi.ToSecret(bucket.Arn))`)
+}
+
+func TestGenStackReference(t *testing.T) {
+	source := `resource network "pulumi:pulumi:StackReference" {
+	name = "acme/network/prod"
+}
+resource unused "pulumi:pulumi:StackReference" {
+	name = "acme/network/dev"
+}
+output vpcId {
+	value = network.outputs["vpcId"]
+}
+`
+	files, diags := generateProgramFromSource(t, source)
+	assert.False(t, diags.HasErrors())
+	main := string(files["main.go"])
+	assert.Contains(t, main, `network, err := pulumi.NewStackReference(ctx, "acme/network/prod", nil)`)
+	assert.Contains(t, main, `_, err = pulumi.NewStackReference(ctx, "acme/network/dev", nil)`)
+	assert.Contains(t, main, `ctx.Export("vpcId", network.GetOutput(pulumi.String("vpcId")))`)
+	assert.NotContains(t, main, "pulumi-pulumi")
+}
+
+func TestGenStackReferenceTypedOutputs(t *testing.T) {
+	source := `resource network "pulumi:pulumi:StackReference" {
+	name = "acme/network/prod"
+}
+resource bucket "aws:s3:Bucket" {
+	bucket = network.outputs["bucketName"]
+	forceDestroy = network.outputs.forceDestroy
+}
+`
+	files, diags := generateProgramFromSource(t, source)
+	assert.False(t, diags.HasErrors())
+	main := string(files["main.go"])
+	assert.Contains(t, main, `Bucket: network.GetStringOutput(pulumi.String("bucketName")),`)
+	assert.Contains(t, main,
+		`ForceDestroy: network.GetOutput(pulumi.String("forceDestroy")).ApplyT(func(v interface{}) bool {`)
+	assert.Contains(t, main, `}).(pulumi.BoolOutput),`)
+}
+
+func TestGenSplitFiles(t *testing.T) {
+	source := `config prefix "string" {}
+resource pet "random:index/randomPet:RandomPet" {
+	prefix = prefix
+}
+resource bucket "aws:s3:Bucket" {
+	bucket = pet.id
+}
+resource logs "aws:s3:Bucket" {
+	bucket = "${bucket.id}-logs"
+}
+output bucketName {
+	value = bucket.id
+}
+`
+	splitFiles, _ := generateProgramFromSourceWithOptions(t, source, GenerateProgramOptions{SplitFiles: true})
+	assert.Len(t, splitFiles, 5)
+
+	main := string(splitFiles["main.go"])
+	assert.Contains(t, main, `prefix = cfg.Require("prefix")`)
+	assert.Contains(t, main, "if err := createRandom(ctx); err != nil {")
+	assert.Contains(t, main, "if err := createAws(ctx); err != nil {")
+	assert.Contains(t, main, `ctx.Export("bucketName", bucket.ID())`)
+	assert.NotContains(t, main, "pulumi-aws")
+
+	aws := string(splitFiles["aws.go"])
+	assert.Contains(t, aws, "bucket *s3.Bucket")
+	assert.Contains(t, aws, "func createAws(ctx *pulumi.Context) error {")
+	assert.Contains(t, aws, "bucket, err = s3.NewBucket(ctx")
+	assert.NotContains(t, aws, "logs *s3.Bucket")
+
+	random := string(splitFiles["random.go"])
+	assert.Contains(t, random, "pet *random.RandomPet")
+	assert.Contains(t, random, "Prefix: pulumi.String(prefix),")
+
+	// Local variables cannot be shared between files.
+	source = `name = "logs"
+resource bucket "aws:s3:Bucket" {
+	bucket = name
+}
+resource pet "random:index/randomPet:RandomPet" {
+	prefix = name
+}
+`
+	files, diags := generateProgramFromSourceWithOptions(t, source, GenerateProgramOptions{SplitFiles: true})
+	assert.Len(t, files, 2)
+	assert.Contains(t, diags.Error(), "local variable name is used by resources from more than one package")
+
+	// The files generated for the first program must make up a single package that builds. This is checked last, as
+	// it is skipped in short test runs.
+	buildProgram(t, splitFiles)
+}
+
+func TestGenNumericRange(t *testing.T) {
+	source := `config replicas "number" {}
+resource bucket "aws:s3:Bucket" {
+	options {
+		range = 3
+	}
+}
+resource replica "aws:s3:Bucket" {
+	options {
+		range = replicas
+	}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, "for index0 := 0; index0 < 3; index0++ {")
+	assert.Contains(t, main, `__res, err := s3.NewBucket(ctx, fmt.Sprintf("bucket-%v", index0), (*s3.BucketArgs)(nil))`)
+	assert.Contains(t, main, "bucket = append(bucket, __res)")
+	assert.Contains(t, main, "for index0 := 0; index0 < int(replicas); index0++ {")
+	assert.NotContains(t, main, "val0")
+	assert.NotContains(t, main, "key0")
+}
+
+func TestGenLeadingComments(t *testing.T) {
+	source := `# The bucket that holds the site's content.
+resource bucket "aws:s3:Bucket" {
+}
+
+/*
+ * The name of the bucket.
+ * This is exported so that other stacks can find the site.
+ */
+output bucketName {
+	value = bucket.id
+}
+
+// The bucket's ARN.
+bucketArn = bucket.arn
+
+output arn {
+	value = bucketArn
+}
+`
+	files, diags := generateProgramFromSource(t, source)
+	assert.Len(t, diags, 0)
+	main := string(files["main.go"])
+	assert.Contains(t, main, "// The bucket that holds the site's content.\n\t\tbucket, err := s3.NewBucket(")
+	assert.Contains(t, main, "// The name of the bucket.\n"+
+		"\t\t// This is exported so that other stacks can find the site.\n"+
+		"\t\tctx.Export(\"bucketName\", bucket.ID())")
+	assert.Contains(t, main, "// The bucket's ARN.\n\t\tbucketArn := bucket.Arn")
+}
+
+func TestGenAdditionalSecretOutputs(t *testing.T) {
+	source := `resource db "aws:rds:Instance" {
+	instanceClass = "db.t3.micro"
+	password = "hunter2"
+	options {
+		protect = true
+		additionalSecretOutputs = [password]
+	}
+}
+`
+	files, diags := generateProgramFromSource(t, source)
+	assert.Len(t, diags, 0)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `pulumi.Protect(true), pulumi.AdditionalSecretOutputs([]string{`)
+	assert.Contains(t, main, `"password",`)
+}
+
+func TestGenLookupInvokeForm(t *testing.T) {
+	source := `vpc = invoke("aws:ec2:getVpc", {
+	default = true
+})
+subnets = invoke("aws:ec2:getSubnetIds", {
+	vpcId = vpc.id
+})
+output subnetIds {
+	value = subnets.ids
+}
+`
+	files, diags := generateProgramFromSource(t, source)
+	assert.Len(t, diags, 0)
+	main := string(files["main.go"])
+	// The aws SDK has both a GetVpc resource getter and a getVpc function, so the function is generated as LookupVpc.
+	// Its arguments type is renamed to match.
+	assert.Contains(t, main, "vpc, err := ec2.LookupVpc(ctx, &ec2.LookupVpcArgs{")
+	// getSubnetIds has no resource of the same name, so it keeps the Get form.
+	assert.Contains(t, main, "subnets, err := ec2.GetSubnetIds(ctx, &ec2.GetSubnetIdsArgs{")
+	assert.NotContains(t, main, "ec2.GetVpc(")
+}
+
+func TestDisambiguateImports(t *testing.T) {
+	const (
+		ec2V1  = `"github.com/pulumi/pulumi-aws/sdk/go/aws/ec2"`
+		ec2V2  = `"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/ec2"`
+		config = `"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/config"`
+		corev1 = `corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v2/go/kubernetes/core/v1"`
+	)
+
+	g := &generator{}
+	g.disambiguateImports(codegen.NewStringSet("fmt"), codegen.NewStringSet(
+		`"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"`, ec2V1, ec2V2, config, corev1))
+
+	// Two versions of the same provider module: the first in sorted order keeps its name.
+	assert.Equal(t, ec2V1, g.importSpec(ec2V1))
+	assert.Equal(t, `ec2v2 "github.com/pulumi/pulumi-aws/sdk/v2/go/aws/ec2"`, g.importSpec(ec2V2))
+	// Pulumi SDK packages always keep their names.
+	assert.Equal(t, `configv2 "github.com/pulumi/pulumi-aws/sdk/v2/go/aws/config"`, g.importSpec(config))
+	assert.Equal(t, corev1, g.importSpec(corev1))
+}
+
+func TestGenProject(t *testing.T) {
+	source := `// The number of days to keep
+// the site's logs.
+config retentionDays "int" {
+	default = 7
+}
+config replicas "number" {}
+config prefix "string" {
+	default = "logs"
+}
+config dbPassword "string" {
+	sensitive = true
+}
+`
+	files, diags := generateProgramFromSourceWithOptions(t, source, GenerateProgramOptions{ModulePath: "example.com/site"})
+	assert.Len(t, diags, 0)
+	// Variables without comments are described by their type alone.
+	assert.Equal(t, `name: site
+runtime: go
+template:
+  config:
+    dbPassword:
+      description: string
+      secret: true
+    prefix:
+      description: string
+      default: logs
+    replicas:
+      description: number
+    retentionDays:
+      description: The number of days to keep the site's logs. (int)
+      default: "7"
+`, string(files["Pulumi.yaml"]))
+
+	// Programs without config variables do not need a Pulumi.yaml.
+	files, _ = generateProgramFromSource(t, `resource bucket "aws:s3:Bucket" {}`)
+	assert.NotContains(t, files, "Pulumi.yaml")
+}
+
+func TestGenArgsStrategy(t *testing.T) {
+	source := `resource bucket "aws:s3:Bucket" {
+	website = {
+		indexDocument = "index.html"
+	}
+}
+resource otherBucket "aws:s3:Bucket" {}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `s3.NewBucket(ctx, "bucket", &s3.BucketArgs{`)
+	assert.Contains(t, main, `s3.NewBucket(ctx, "otherBucket", (*s3.BucketArgs)(nil))`)
+
+	files, _ = generateProgramFromSourceWithOptions(t, source, GenerateProgramOptions{ArgsStrategy: ValueArgs{}})
+	main = string(files["main.go"])
+	assert.Contains(t, main, `s3.NewBucket(ctx, "bucket", s3.BucketArgs{`)
+	assert.Contains(t, main, `s3.NewBucket(ctx, "otherBucket", s3.BucketArgs{})`)
+	assert.NotContains(t, main, `&s3.BucketArgs`)
+}
+
+func TestGenForExpressions(t *testing.T) {
+	source := `names = ["alpha", "beta", "gamma"]
+suffixed = [for name in names : "${name}-suffix"]
+indexed = {for i, name in names : name => "${name}-${i}" if i > 0}
+
+output suffixedNames {
+	value = suffixed
+}
+output indexedNames {
+	value = indexed
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// The list comprehension is lowered into a loop that appends to a slice.
+	assert.Contains(t, main, "var for0 []string")
+	assert.Contains(t, main, "for _, name := range names {")
+	assert.Contains(t, main, `for0 = append(for0, fmt.Sprintf(`)
+	assert.Contains(t, main, "suffixed := for0")
+
+	// The map comprehension is lowered into a loop that populates a map, skipping filtered elements.
+	assert.Contains(t, main, "for1 := make(map[string]string)")
+	assert.Contains(t, main, "for i, name := range names {\n\t\t\ti := float64(i)")
+	assert.Contains(t, main, "if !(i > 0) {")
+	assert.Contains(t, main, `for1[name] = fmt.Sprintf(`)
+	assert.Contains(t, main, "indexed := for1")
+
+	outputs := runProgram(t, files)
+	assert.Equal(t, map[string]string{
+		"suffixedNames": "[alpha-suffix beta-suffix gamma-suffix]",
+		"indexedNames":  "map[beta:beta-1 gamma:gamma-2]",
+	}, outputs)
+}
+
+func TestGenForExpressionsOverMaps(t *testing.T) {
+	source := `tags = {
+	env = "prod"
+	team = "web"
+}
+pairs = [for k, v in tags : "${k}=${v}"]
+
+output tagPairs {
+	value = pairs
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// Maps are ranged over in the order of their sorted keys.
+	assert.Contains(t, main, `"sort"`)
+	assert.Contains(t, main, "for0Collection := tags")
+	assert.Contains(t, main, "sort.Strings(for0Keys)")
+	assert.Contains(t, main, "for _, k := range for0Keys {\n\t\t\tv := for0Collection[k]")
+
+	outputs := runProgram(t, files)
+	assert.Equal(t, map[string]string{"tagPairs": "[env=prod team=web]"}, outputs)
+}
+
+func TestGenForExpressionsInApplies(t *testing.T) {
+	source := `resource shuffle "random:index/randomShuffle:RandomShuffle" {
+	inputs = ["alpha", "beta"]
+}
+
+output suffixed {
+	value = [for v in shuffle.inputs : "${v}-suffix"]
+}
+output indexed {
+	value = {for i, v in shuffle.inputs : v => i}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// For expressions over outputs are lowered into loops inside the apply that resolves the output.
+	assert.Contains(t, main, "shuffle.Inputs.ApplyT(func(inputs []string) ([]string, error) {\n\t\t\tvar for0 []string")
+	assert.Contains(t, main, "}).(pulumi.StringArrayOutput)")
+	assert.Contains(t, main, "shuffle.Inputs.ApplyT(func(inputs []string) (map[string]float64, error) {")
+	assert.Contains(t, main, "}).(pulumi.Float64MapOutput)")
+
+	outputs := runProgram(t, files)
+	assert.Equal(t, map[string]string{
+		"suffixed": "[alpha-suffix beta-suffix]",
+		"indexed":  "map[alpha:0 beta:1]",
+	}, outputs)
+}
+
+func TestGenTryAndCan(t *testing.T) {
+	source := `names = [for name in ["alpha"] : name]
+counts = [for n in [1, 2] : n]
+second = try(names[1], names[2], "default")
+third = try(counts[2], 0)
+hasFirst = can(names[0])
+hasSecond = can(names[1])
+
+output secondName {
+	value = second
+}
+output thirdCount {
+	value = third
+}
+output firstExists {
+	value = hasFirst
+}
+output secondExists {
+	value = hasSecond
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// Each argument to try is evaluated lazily and converted to the type of the call, so that the result can be
+	// converted back to that type whichever argument produced it.
+	assert.Contains(t, main, "second := try(func() interface{} { return names[1] }, "+
+		"func() interface{} { return names[2] }, func() interface{} { return \"default\" }).(string)")
+	assert.Contains(t, main, "third := try(func() interface{} { return counts[2] }, "+
+		"func() interface{} { return float64(0) }).(float64)")
+	assert.Contains(t, main, "hasSecond := can(func() interface{} { return names[1] })")
+
+	// The helpers are emitted once after main.
+	assert.Equal(t, 1, strings.Count(main, "func try(fns ...func() interface{}) interface{} {"))
+	assert.Equal(t, 1, strings.Count(main, "func can(fn func() interface{}) bool {"))
+	assert.Equal(t, 1, strings.Count(main, "func attempt(fn func() interface{}) (v interface{}, ok bool) {"))
+
+	// Out-of-range indices fall back to the next argument.
+	outputs := runProgram(t, files)
+	assert.Equal(t, map[string]string{
+		"secondName":   "default",
+		"thirdCount":   "0",
+		"firstExists":  "true",
+		"secondExists": "false",
+	}, outputs)
+}
+
+func TestGenTryOutputs(t *testing.T) {
+	source := `resource pet "random:index/randomPet:RandomPet" {}
+petName = try(pet.id, "default")
+
+output name {
+	value = petName
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// Output arguments and prompt arguments are both converted to the output type of the call.
+	assert.Contains(t, main, "petName := try(func() interface{} { return pet.ID().ToStringOutput() }, "+
+		"func() interface{} { return pulumi.String(\"default\").ToStringOutput() }).(pulumi.StringOutput)")
+
+	outputs := runProgram(t, files)
+	assert.Equal(t, map[string]string{"name": "pet-id"}, outputs)
+}
+
+func TestGenTryHelperNames(t *testing.T) {
+	source := `names = [for name in ["alpha"] : name]
+can = "can"
+attempt = try(names[1], can)
+
+output result {
+	value = attempt
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// Program variables are renamed so that they do not shadow the helpers.
+	assert.Contains(t, main, "can2 := \"can\"")
+	assert.Contains(t, main, "attempt2 := try(")
+	buildProgram(t, files)
+}
+
+func TestGenHeterogeneousCollections(t *testing.T) {
+	source := `resource pet "random:index/randomPet:RandomPet" {
+	keepers = {
+		name = "doggo"
+		count = 2
+		tags = ["a", 1]
+	}
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// Maps and lists of dynamically-typed or mixed-type values are emitted as pulumi.Map and pulumi.Array, with each
+	// element wrapped in its own input type.
+	assert.Contains(t, main, "Keepers: pulumi.Map{")
+	assert.Contains(t, main, `pulumi.String("doggo")`)
+	assert.Contains(t, main, "pulumi.Array{")
+	assert.Contains(t, main, `pulumi.String("a")`)
+	assert.NotContains(t, main, "pulumi.AnyMap")
+	assert.NotContains(t, main, "pulumi.AnyArray")
+	assert.NotContains(t, main, "pulumi.Pulumi")
+
+	buildProgram(t, files)
+}
+
+func TestGenErrScopes(t *testing.T) {
+	source := `resource ranged "aws:s3:Bucket" {
+	options {
+		range = ["a", "b"]
+	}
+}
+resource top "aws:s3:Bucket" {}
+resource conditional "aws:s3:Bucket" {
+	options {
+		range = true
+	}
+}
+resource after "aws:s3:Bucket" {}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+
+	// The err declared inside the loop is not visible after it, so the next top-level resource must declare err.
+	assert.Contains(t, main, `__res, err := s3.NewBucket(ctx, fmt.Sprintf("ranged-%v", key0), (*s3.BucketArgs)(nil))`)
+	assert.Contains(t, main, `_, err := s3.NewBucket(ctx, "top", (*s3.BucketArgs)(nil))`)
+
+	// Subsequent resources reuse the top-level err, including those created inside conditional blocks.
+	assert.Contains(t, main, "if true {")
+	assert.Contains(t, main, `_, err = s3.NewBucket(ctx, "conditional", (*s3.BucketArgs)(nil))`)
+	assert.Contains(t, main, `_, err = s3.NewBucket(ctx, "after", (*s3.BucketArgs)(nil))`)
+
+	buildProgram(t, files)
+}
+
+func TestGenDeterministic(t *testing.T) {
+	// The local named `_zero` forces the zero-value temporary in the policy's apply to be renamed.
+	source := `_zero = "zero"
+resource bucket "aws:s3:Bucket" {}
+resource bucketPolicy "aws:s3:BucketPolicy" {
+	bucket = bucket.id
+	policy = toJSON({
+		Resource = "arn:aws:s3:::${bucket.id}/${_zero}"
+	})
+}
+`
+	files, _ := generateProgramFromSource(t, source)
+	main := string(files["main.go"])
+	assert.Contains(t, main, `var _zero2 pulumi.String`)
+	assert.Contains(t, main, `return _zero2, err`)
+
+	for i := 0; i < 5; i++ {
+		again, _ := generateProgramFromSource(t, source)
+		assert.Equal(t, main, string(again["main.go"]))
+	}
+}
+
+func TestGenHelpersDeterministic(t *testing.T) {
+	g := newTestGenerator(t, "aws-s3-logging.pp")
+	for _, destType := range []string{"pulumi.StringArray", "pulumi.BoolArray", "pulumi.IntArray", "pulumi.Float64Array"} {
+		g.arrayHelpers[destType] = &promptToInputArrayHelper{destType: destType}
+	}
+
+	var first bytes.Buffer
+	g.genHelpers(&first)
+	helpers := first.String()
+	assert.True(t, strings.Index(helpers, "func toPulumiBoolArray") < strings.Index(helpers, "func toPulumiFloat64Array"))
+	assert.True(t, strings.Index(helpers, "func toPulumiIntArray") < strings.Index(helpers, "func toPulumiStringArray"))
+
+	for i := 0; i < 10; i++ {
+		var again bytes.Buffer
+		g.genHelpers(&again)
+		assert.Equal(t, helpers, again.String())
+	}
+}
+
 func TestCollectImports(t *testing.T) {
 	g := newTestGenerator(t, "aws-s3-logging.pp")
 	pulumiImports := codegen.NewStringSet()
@@ -119,12 +908,51 @@ func newTestGenerator(t *testing.T, testFile string) *generator {
 			readDirTempSpiller:  &readDirSpiller{},
 			splatSpiller:        &splatSpiller{},
 			optionalSpiller:     &optionalSpiller{},
+			forSpiller:          &forSpiller{},
 			scopeTraversalRoots: codegen.NewStringSet(),
 			arrayHelpers:        make(map[string]*promptToInputArrayHelper),
+			identifiers:         make(map[string]string),
+			argsStrategy:        PointerArgs{},
 		}
 		g.Formatter = format.NewFormatter(g)
+		g.collectIdentifiers(program)
 		return g
 	}
 	t.Fatalf("test file not found")
 	return nil
 }
+
+// generateProgramFromSource binds the given HCL2 source against the test schemas and generates a Go program from it.
+func generateProgramFromSource(t *testing.T, source string) (map[string][]byte, hcl.Diagnostics) {
+	return generateProgramFromSourceWithOptions(t, source, GenerateProgramOptions{})
+}
+
+func generateProgramFromSourceWithOptions(t *testing.T, source string,
+	opts GenerateProgramOptions) (map[string][]byte, hcl.Diagnostics) {
+
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(source), "main.pp")
+	if err != nil {
+		t.Fatalf("could not parse source: %v", err)
+	}
+	if parser.Diagnostics.HasErrors() {
+		t.Fatalf("failed to parse files: %v", parser.Diagnostics)
+	}
+
+	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.PluginHost(test.NewHost(testdataPath)))
+	if err != nil {
+		t.Fatalf("could not bind program: %v", err)
+	}
+	if diags.HasErrors() {
+		t.Fatalf("failed to bind program: %v", diags)
+	}
+
+	files, diags, err := GenerateProgramWithOptions(program, opts)
+	if err != nil {
+		t.Fatalf("could not generate program: %v", err)
+	}
+	if diags.HasErrors() {
+		t.Fatalf("failed to generate program: %v", diags)
+	}
+	return files, diags
+}
@@ -54,3 +54,40 @@ func (p *promptToInputArrayHelper) getPromptItemType() string {
 func (p *promptToInputArrayHelper) getInputItemType() string {
 	return strings.TrimSuffix(p.destType, "Array")
 }
+
+// generateTryHelper generates the helper used to implement HCL's try function, which returns the result of the first
+// of its arguments that evaluates without panicking.
+func generateTryHelper(w io.Writer) {
+	fmt.Fprintf(w, "func try(fns ...func() interface{}) interface{} {\n")
+	fmt.Fprintf(w, "for _, fn := range fns {\n")
+	fmt.Fprintf(w, "if v, ok := attempt(fn); ok {\n")
+	fmt.Fprintf(w, "return v\n")
+	fmt.Fprintf(w, "}\n")
+	fmt.Fprintf(w, "}\n")
+	fmt.Fprintf(w, "panic(\"no expression passed to try evaluated successfully\")\n")
+	fmt.Fprintf(w, "}\n")
+}
+
+// generateCanHelper generates the helper used to implement HCL's can function, which returns true if its argument
+// evaluates without panicking.
+func generateCanHelper(w io.Writer) {
+	fmt.Fprintf(w, "func can(fn func() interface{}) bool {\n")
+	fmt.Fprintf(w, "_, ok := attempt(fn)\n")
+	fmt.Fprintf(w, "return ok\n")
+	fmt.Fprintf(w, "}\n")
+}
+
+// generateAttemptHelper generates the helper shared by try and can, which evaluates a function and recovers from any
+// panic that occurs during its evaluation. Only errors that the generated Go code reports by panicking are caught, such
+// as an out-of-range index or a failed type assertion. Unlike in HCL, indexing a map with a missing key is not an error
+// in Go, so try and can treat it as a success that yields the zero value.
+func generateAttemptHelper(w io.Writer) {
+	fmt.Fprintf(w, "func attempt(fn func() interface{}) (v interface{}, ok bool) {\n")
+	fmt.Fprintf(w, "defer func() {\n")
+	fmt.Fprintf(w, "if r := recover(); r != nil {\n")
+	fmt.Fprintf(w, "v, ok = nil, false\n")
+	fmt.Fprintf(w, "}\n")
+	fmt.Fprintf(w, "}()\n")
+	fmt.Fprintf(w, "return fn(), true\n")
+	fmt.Fprintf(w, "}\n")
+}
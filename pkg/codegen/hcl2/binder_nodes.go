@@ -108,6 +108,8 @@ func (b *binder) bindConfigVariable(node *ConfigVariable) hcl.Diagnostics {
 			diagnostics = append(diagnostics, model.ExprNotConvertible(model.InputType(node.typ), node.DefaultValue))
 		}
 	}
+	sensitive, diags := bindSensitive(block)
+	node.Sensitive, diagnostics = sensitive, append(diagnostics, diags...)
 	node.Definition = block
 	return diagnostics
 }
@@ -126,6 +128,21 @@ func (b *binder) bindOutputVariable(node *OutputVariable) hcl.Diagnostics {
 			diagnostics = append(diagnostics, model.ExprNotConvertible(model.InputType(node.typ), node.Value))
 		}
 	}
+	sensitive, diags := bindSensitive(block)
+	node.Sensitive, diagnostics = sensitive, append(diagnostics, diags...)
 	node.Definition = block
 	return diagnostics
 }
+
+// bindSensitive returns the value of the given block's `sensitive` attribute, which must be a literal bool if it is
+// present.
+func bindSensitive(block *model.Block) (bool, hcl.Diagnostics) {
+	sensitive, ok := block.Body.Attribute("sensitive")
+	if !ok {
+		return false, nil
+	}
+	if lit, ok := sensitive.Value.(*model.LiteralValueExpression); ok && lit.Type() == model.BoolType {
+		return lit.Value.True(), nil
+	}
+	return false, hcl.Diagnostics{sensitiveMustBeBoolLiteral(sensitive.Value)}
+}
@@ -54,6 +54,20 @@ func (b *binder) bindResourceTypes(node *Resource) hcl.Diagnostics {
 		return diagnostics
 	}
 
+	if token == StackReferenceToken {
+		node.Token = token
+		node.InputType = model.InputType(model.NewObjectType(map[string]model.Type{
+			"name": model.StringType,
+		}))
+		node.OutputType = model.NewObjectType(map[string]model.Type{
+			"id":      model.NewOutputType(model.StringType),
+			"urn":     model.NewOutputType(model.StringType),
+			"name":    model.NewOutputType(model.StringType),
+			"outputs": model.NewMapType(model.NewOutputType(model.DynamicType)),
+		})
+		return diagnostics
+	}
+
 	isProvider := false
 	if pkg == "pulumi" && module == "providers" {
 		pkg, isProvider = name, true
@@ -149,23 +163,31 @@ func (s *optionsScopes) GetScopesForBlock(block *hclsyntax.Block) (model.Scopes,
 }
 
 func (s *optionsScopes) GetScopeForAttribute(attr *hclsyntax.Attribute) (*model.Scope, hcl.Diagnostics) {
-	if attr.Name == "ignoreChanges" {
-		obj, ok := model.ResolveOutputs(s.resource.InputType).(*model.ObjectType)
-		if !ok {
-			return nil, nil
-		}
-		scope := model.NewRootScope(syntax.None)
-		for k, t := range obj.Properties {
-			scope.Define(k, &ResourceProperty{
-				Path:         hcl.Traversal{hcl.TraverseRoot{Name: k}},
-				PropertyType: t,
-			})
-		}
-		return scope, nil
+	switch attr.Name {
+	case "ignoreChanges":
+		return propertiesScope(s.resource.InputType), nil
+	case "additionalSecretOutputs":
+		return propertiesScope(s.resource.OutputType), nil
 	}
 	return s.root, nil
 }
 
+// propertiesScope returns a scope that defines a ResourceProperty for each property of the given object type.
+func propertiesScope(typ model.Type) *model.Scope {
+	obj, ok := model.ResolveOutputs(typ).(*model.ObjectType)
+	if !ok {
+		return nil
+	}
+	scope := model.NewRootScope(syntax.None)
+	for k, t := range obj.Properties {
+		scope.Define(k, &ResourceProperty{
+			Path:         hcl.Traversal{hcl.TraverseRoot{Name: k}},
+			PropertyType: t,
+		})
+	}
+	return scope
+}
+
 // bindResourceBody binds the body of a resource.
 func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 	var diagnostics hcl.Diagnostics
@@ -313,6 +335,24 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 				case "ignoreChanges":
 					t = model.NewListType(ResourcePropertyType)
 					resourceOptions.IgnoreChanges = item.Value
+				case "deleteBeforeReplace":
+					t = model.BoolType
+					resourceOptions.DeleteBeforeReplace = item.Value
+				case "retainOnDelete":
+					t = model.BoolType
+					resourceOptions.RetainOnDelete = item.Value
+				case "import":
+					t = model.StringType
+					resourceOptions.Import = item.Value
+				case "aliases":
+					t = model.NewListType(AliasType)
+					resourceOptions.Aliases = item.Value
+				case "customTimeouts":
+					t = CustomTimeoutsType
+					resourceOptions.CustomTimeouts = item.Value
+				case "additionalSecretOutputs":
+					t = model.NewListType(ResourcePropertyType)
+					resourceOptions.AdditionalSecretOutputs = item.Value
 				default:
 					diagnostics = append(diagnostics, unsupportedAttribute(item.Name, item.Syntax.NameRange))
 					continue
@@ -32,6 +32,8 @@ type ConfigVariable struct {
 	Definition *model.Block
 	// The default value for the config variable, if any.
 	DefaultValue model.Expression
+	// True if the value of the config variable is sensitive and should be stored as a secret.
+	Sensitive bool
 }
 
 // SyntaxNode returns the syntax node associated with the config variable.
@@ -65,6 +65,10 @@ func tokenMustBeStringLiteral(tokenExpr model.Expression) *hcl.Diagnostic {
 	return errorf(tokenExpr.SyntaxNode().Range(), "invoke token must be a string literal")
 }
 
+func sensitiveMustBeBoolLiteral(sensitiveExpr model.Expression) *hcl.Diagnostic {
+	return errorf(sensitiveExpr.SyntaxNode().Range(), "sensitive must be a boolean literal")
+}
+
 func duplicateBlock(blockType string, typeRange hcl.Range) *hcl.Diagnostic {
 	return errorf(typeRange, "duplicate block of type '%v'", blockType)
 }
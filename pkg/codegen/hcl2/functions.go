@@ -41,6 +41,13 @@ func getEntriesSignature(args []model.Expression) (model.StaticFunctionSignature
 }
 
 var pulumiBuiltins = map[string]*model.Function{
+	"can": model.NewFunction(model.StaticFunctionSignature{
+		Parameters: []model.Parameter{{
+			Name: "expression",
+			Type: model.DynamicType,
+		}},
+		ReturnType: model.BoolType,
+	}),
 	"element": model.NewFunction(model.GenericFunctionSignature(
 		func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
 			var diagnostics hcl.Diagnostics
@@ -231,4 +238,25 @@ var pulumiBuiltins = map[string]*model.Function{
 		}},
 		ReturnType: model.StringType,
 	}),
+	"try": model.NewFunction(model.GenericFunctionSignature(
+		func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+			// The result of try is the result of the first argument that evaluates successfully, so its type is the
+			// unification of the types of its arguments.
+			returnType := model.Type(model.DynamicType)
+			if len(args) > 0 {
+				argTypes := make([]model.Type, len(args))
+				for i, arg := range args {
+					argTypes[i] = arg.Type()
+				}
+				returnType, _ = model.UnifyTypes(argTypes...)
+			}
+
+			return model.StaticFunctionSignature{
+				VarargsParameter: &model.Parameter{
+					Name: "expressions",
+					Type: returnType,
+				},
+				ReturnType: returnType,
+			}, nil
+		})),
 }
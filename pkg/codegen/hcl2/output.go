@@ -31,6 +31,8 @@ type OutputVariable struct {
 	Definition *model.Block
 	// The value of the output.
 	Value model.Expression
+	// True if the value of the output is sensitive and should be stored as a secret.
+	Sensitive bool
 }
 
 // SyntaxNode returns the syntax node associated with the output variable.
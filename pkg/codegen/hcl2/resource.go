@@ -22,6 +22,10 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 )
 
+// StackReferenceToken is the type token of a reference to another stack. A stack reference's name input is the fully
+// qualified name of the referenced stack, and its outputs property is a map of that stack's outputs.
+const StackReferenceToken = "pulumi:pulumi:StackReference"
+
 // ResourceOptions represents a resource instantiation's options.
 type ResourceOptions struct {
 	// The definition of the resource options.
@@ -39,6 +43,18 @@ type ResourceOptions struct {
 	Protect model.Expression
 	// A list of properties that are not considered when diffing the resource.
 	IgnoreChanges model.Expression
+	// Whether or not the resource should be deleted before it is replaced.
+	DeleteBeforeReplace model.Expression
+	// Whether or not deleting the resource should leave the cloud resource intact.
+	RetainOnDelete model.Expression
+	// The ID of an existing cloud resource to adopt rather than create.
+	Import model.Expression
+	// The previous identities of the resource, if any.
+	Aliases model.Expression
+	// Custom timeouts for the resource's create, update, and delete operations.
+	CustomTimeouts model.Expression
+	// A list of output properties that should be treated as secrets.
+	AdditionalSecretOutputs model.Expression
 }
 
 // Resource represents a resource instantiation inside of a program or component.
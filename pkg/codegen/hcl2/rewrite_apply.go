@@ -143,6 +143,15 @@ func (r *applyRewriter) isIteratorExpr(x model.Expression) (bool, model.Type) {
 	}
 }
 
+// parameterType returns the type of the i'th parameter of the given signature. Arguments past the signature's fixed
+// parameters are typed by its varargs parameter.
+func parameterType(signature model.StaticFunctionSignature, i int) model.Type {
+	if i < len(signature.Parameters) {
+		return signature.Parameters[i].Type
+	}
+	return signature.VarargsParameter.Type
+}
+
 func (r *applyRewriter) inspectsEventualValues(x model.Expression) bool {
 	switch x := x.(type) {
 	case *model.ConditionalExpression:
@@ -155,7 +164,7 @@ func (r *applyRewriter) inspectsEventualValues(x model.Expression) bool {
 			return true
 		}
 		for i, arg := range x.Args {
-			if r.hasEventualValues(arg) && r.isPromptArg(x.Signature.Parameters[i].Type, arg) {
+			if r.hasEventualValues(arg) && r.isPromptArg(parameterType(x.Signature, i), arg) {
 				return true
 			}
 		}
@@ -190,7 +199,7 @@ func (r *applyRewriter) observesEventualValues(x model.Expression) bool {
 		return collectionIsEventual
 	case *model.FunctionCallExpression:
 		for i, arg := range x.Args {
-			if !r.isPromptArg(x.Signature.Parameters[i].Type, arg) {
+			if !r.isPromptArg(parameterType(x.Signature, i), arg) {
 				return true
 			}
 		}
@@ -25,4 +25,19 @@ var (
 	AssetType model.Type = model.MustNewOpaqueType("Asset")
 	// ResourcePropertyType represents a resource property reference.
 	ResourcePropertyType model.Type = model.MustNewOpaqueType("Property")
+	// AliasType represents an entry in a resource's list of aliases. Each property that is present replaces the
+	// corresponding part of the resource's current URN.
+	AliasType model.Type = model.NewObjectType(map[string]model.Type{
+		"name":    model.NewOptionalType(model.StringType),
+		"type":    model.NewOptionalType(model.StringType),
+		"parent":  model.NewOptionalType(model.DynamicType),
+		"stack":   model.NewOptionalType(model.StringType),
+		"project": model.NewOptionalType(model.StringType),
+	})
+	// CustomTimeoutsType represents a resource's custom timeouts. Each timeout is a duration string such as "30m".
+	CustomTimeoutsType model.Type = model.NewObjectType(map[string]model.Type{
+		"create": model.NewOptionalType(model.StringType),
+		"update": model.NewOptionalType(model.StringType),
+		"delete": model.NewOptionalType(model.StringType),
+	})
 )
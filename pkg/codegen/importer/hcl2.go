@@ -25,6 +25,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -348,6 +349,15 @@ func generatePropertyValue(property *schema.Property, value resource.PropertyVal
 		return zeroValue(property.Type), nil
 	}
 
+	// Be lenient about minor mismatches between the value and the property's type (e.g. a number where a string is
+	// expected). Provider state does not always match the provider's input types--for example, an archive input may
+	// be stored as a string--so a value that cannot be coerced is generated as-is rather than failing the import.
+	if coerced, err := schema.CoercePropertyValue(value, property.Type); err == nil {
+		value = coerced
+	} else {
+		logging.V(5).Infof("generating property %v as-is: %v", property.Name, err)
+	}
+
 	return generateValue(property.Type, value)
 }
 
@@ -274,6 +274,40 @@ func TestGenerateHCL2Definition(t *testing.T) {
 	}
 }
 
+func TestGeneratePropertyValueCoercion(t *testing.T) {
+	cases := []struct {
+		typ      schema.Type
+		value    resource.PropertyValue
+		expected resource.PropertyValue
+	}{
+		// Values that are the wrong type are coerced to the property's type where that is safe.
+		{schema.StringType, resource.NewNumberProperty(42), resource.NewStringProperty("42")},
+		{schema.IntType, resource.NewStringProperty("8080"), resource.NewNumberProperty(8080)},
+		{
+			&schema.ArrayType{ElementType: schema.StringType},
+			resource.NewStringProperty("a"),
+			resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("a")}),
+		},
+		{
+			&schema.ObjectType{Properties: []*schema.Property{{Name: "port", Type: schema.IntType}}},
+			resource.NewObjectProperty(resource.PropertyMap{"port": resource.NewStringProperty("80")}),
+			resource.NewObjectProperty(resource.PropertyMap{"port": resource.NewNumberProperty(80)}),
+		},
+		// Values that cannot be coerced are generated as-is.
+		{schema.IntType, resource.NewStringProperty("1.5"), resource.NewStringProperty("1.5")},
+		{schema.ArchiveType, resource.NewStringProperty("code"), resource.NewStringProperty("code")},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v to %v", c.value, c.typ), func(t *testing.T) {
+			x, err := generatePropertyValue(&schema.Property{Name: "p", Type: c.typ}, c.value)
+			if !assert.NoError(t, err) {
+				t.Fatal()
+			}
+			assert.True(t, c.expected.DeepEquals(renderExpr(t, x)), "got %v", renderExpr(t, x))
+		})
+	}
+}
+
 func TestSimplerType(t *testing.T) {
 	types := []schema.Type{
 		schema.BoolType,
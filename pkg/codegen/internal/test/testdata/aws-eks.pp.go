@@ -13,6 +13,7 @@ import (
 
 func main() {
 	pulumi.Run(func(ctx *pulumi.Context) error {
+		// VPC
 		eksVpc, err := ec2.NewVpc(ctx, "eksVpc", &ec2.VpcArgs{
 			CidrBlock:          pulumi.String("10.100.0.0/16"),
 			InstanceTenancy:    pulumi.String("default"),
@@ -49,6 +50,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// Subnets, one for each AZ in a region
 		zones, err := aws.GetAvailabilityZones(ctx, nil, nil)
 		if err != nil {
 			return err
@@ -116,6 +118,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// EKS Cluster Role
 		tmpJSON0, err := json.Marshal(map[string]interface{}{
 			"Version": "2012-10-17",
 			"Statement": []map[string]interface{}{
@@ -153,6 +156,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// EC2 NodeGroup Role
 		tmpJSON1, err := json.Marshal(map[string]interface{}{
 			"Version": "2012-10-17",
 			"Statement": []map[string]interface{}{
@@ -197,6 +201,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// EKS Cluster
 		eksCluster, err := eks.NewCluster(ctx, "eksCluster", &eks.ClusterArgs{
 			RoleArn: eksRole.Arn,
 			Tags: pulumi.StringMap{
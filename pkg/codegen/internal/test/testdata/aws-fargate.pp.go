@@ -12,6 +12,7 @@ import (
 
 func main() {
 	pulumi.Run(func(ctx *pulumi.Context) error {
+		// Read the default VPC and public subnets, which we will use.
 		opt0 := true
 		vpc, err := ec2.LookupVpc(ctx, &ec2.LookupVpcArgs{
 			Default: &opt0,
@@ -25,6 +26,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// Create a security group that permits HTTP ingress and unrestricted egress.
 		webSecurityGroup, err := ec2.NewSecurityGroup(ctx, "webSecurityGroup", &ec2.SecurityGroupArgs{
 			VpcId: pulumi.String(vpc.Id),
 			Egress: ec2.SecurityGroupEgressArray{
@@ -51,10 +53,12 @@ func main() {
 		if err != nil {
 			return err
 		}
-		cluster, err := ecs.NewCluster(ctx, "cluster", nil)
+		// Create an ECS cluster to run a container-based service.
+		cluster, err := ecs.NewCluster(ctx, "cluster", (*ecs.ClusterArgs)(nil))
 		if err != nil {
 			return err
 		}
+		// Create an IAM role that can be used by our service's task.
 		tmpJSON0, err := json.Marshal(map[string]interface{}{
 			"Version": "2008-10-17",
 			"Statement": []map[string]interface{}{
@@ -85,6 +89,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// Create a load balancer to listen for HTTP traffic on port 80.
 		webLoadBalancer, err := elasticloadbalancingv2.NewLoadBalancer(ctx, "webLoadBalancer", &elasticloadbalancingv2.LoadBalancerArgs{
 			Subnets: toPulumiStringArray(subnets.Ids),
 			SecurityGroups: pulumi.StringArray{
@@ -116,6 +121,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// Spin up a load balanced service running NGINX
 		tmpJSON1, err := json.Marshal([]map[string]interface{}{
 			map[string]interface{}{
 				"name":  "my-app",
@@ -172,6 +178,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// Export the resulting web address.
 		ctx.Export("url", webLoadBalancer.DnsName)
 		return nil
 	})
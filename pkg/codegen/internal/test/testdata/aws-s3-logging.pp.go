@@ -7,7 +7,7 @@ import (
 
 func main() {
 	pulumi.Run(func(ctx *pulumi.Context) error {
-		logs, err := s3.NewBucket(ctx, "logs", nil)
+		logs, err := s3.NewBucket(ctx, "logs", (*s3.BucketArgs)(nil))
 		if err != nil {
 			return err
 		}
@@ -10,6 +10,7 @@ import (
 
 func main() {
 	pulumi.Run(func(ctx *pulumi.Context) error {
+		// Create a new security group for port 80.
 		securityGroup, err := ec2.NewSecurityGroup(ctx, "securityGroup", &ec2.SecurityGroupArgs{
 			Ingress: ec2.SecurityGroupIngressArray{
 				&ec2.SecurityGroupIngressArgs{
@@ -25,6 +26,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// Get the ID for the latest Amazon Linux AMI.
 		opt0 := true
 		ami, err := aws.GetAmi(ctx, &aws.GetAmiArgs{
 			Filters: []aws.GetAmiFilter{
@@ -43,6 +45,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// Create a simple web server using the startup script for the instance.
 		server, err := ec2.NewInstance(ctx, "server", &ec2.InstanceArgs{
 			Tags: pulumi.StringMap{
 				"Name": pulumi.String("web-server-www"),
@@ -57,6 +60,7 @@ func main() {
 		if err != nil {
 			return err
 		}
+		// Export the resulting server's IP address and DNS name.
 		ctx.Export("publicIp", server.PublicIp)
 		ctx.Export("publicHostName", server.PublicDns)
 		return nil
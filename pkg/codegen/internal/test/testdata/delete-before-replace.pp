@@ -0,0 +1,5 @@
+resource bucket "aws:s3:Bucket" {
+	options {
+		deleteBeforeReplace = true
+	}
+}
@@ -0,0 +1,16 @@
+using Pulumi;
+using Aws = Pulumi.Aws;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var bucket = new Aws.S3.Bucket("bucket", new Aws.S3.BucketArgs
+        {
+        }, new CustomResourceOptions
+        {
+            DeleteBeforeReplace = true,
+        });
+    }
+
+}
@@ -0,0 +1,4 @@
+import pulumi
+import pulumi_aws as aws
+
+bucket = aws.s3.Bucket("bucket", opts=ResourceOptions(delete_before_replace=True))
@@ -0,0 +1,6 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const bucket = new aws.s3.Bucket("bucket", {}, {
+    deleteBeforeReplace: true,
+});
@@ -0,0 +1,12 @@
+resource bucket "aws:s3:Bucket" {
+	options {
+		retainOnDelete = true
+	}
+}
+
+resource logs "aws:s3:Bucket" {
+	options {
+		protect = true
+		retainOnDelete = true
+	}
+}
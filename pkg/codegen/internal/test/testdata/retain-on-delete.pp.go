@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		_, err := s3.NewBucket(ctx, "bucket", (*s3.BucketArgs)(nil), pulumi.RetainOnDelete(true))
+		if err != nil {
+			return err
+		}
+		_, err = s3.NewBucket(ctx, "logs", (*s3.BucketArgs)(nil), pulumi.Protect(true), pulumi.RetainOnDelete(true))
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
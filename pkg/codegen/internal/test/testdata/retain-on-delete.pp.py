@@ -0,0 +1,6 @@
+import pulumi
+import pulumi_aws as aws
+
+bucket = aws.s3.Bucket("bucket", opts=ResourceOptions(retain_on_delete=True))
+logs = aws.s3.Bucket("logs", opts=ResourceOptions(protect=True,
+    retain_on_delete=True))
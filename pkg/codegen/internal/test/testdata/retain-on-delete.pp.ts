@@ -0,0 +1,10 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const bucket = new aws.s3.Bucket("bucket", {}, {
+    retainOnDelete: true,
+});
+const logs = new aws.s3.Bucket("logs", {}, {
+    protect: true,
+    retainOnDelete: true,
+});
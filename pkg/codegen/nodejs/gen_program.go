@@ -282,6 +282,24 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	if opts.IgnoreChanges != nil {
 		appendOption("ignoreChanges", opts.IgnoreChanges)
 	}
+	if opts.DeleteBeforeReplace != nil {
+		appendOption("deleteBeforeReplace", opts.DeleteBeforeReplace)
+	}
+	if opts.RetainOnDelete != nil {
+		appendOption("retainOnDelete", opts.RetainOnDelete)
+	}
+	if opts.Import != nil {
+		appendOption("import", opts.Import)
+	}
+	if opts.Aliases != nil {
+		appendOption("aliases", opts.Aliases)
+	}
+	if opts.CustomTimeouts != nil {
+		appendOption("customTimeouts", opts.CustomTimeouts)
+	}
+	if opts.AdditionalSecretOutputs != nil {
+		appendOption("additionalSecretOutputs", opts.AdditionalSecretOutputs)
+	}
 
 	if object == nil {
 		return ""
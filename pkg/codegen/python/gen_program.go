@@ -256,6 +256,28 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 	if opts.IgnoreChanges != nil {
 		appendOption("ignore_changes", opts.IgnoreChanges)
 	}
+	if opts.DeleteBeforeReplace != nil {
+		appendOption("delete_before_replace", opts.DeleteBeforeReplace)
+	}
+	if opts.RetainOnDelete != nil {
+		appendOption("retain_on_delete", opts.RetainOnDelete)
+	}
+	if opts.Import != nil {
+		appendOption("import_", opts.Import)
+	}
+	if opts.Aliases != nil {
+		// Aliases must be generated as pulumi.Alias objects rather than dicts.
+		g.diagnostics = append(g.diagnostics,
+			codegen.UnsupportedOption(opts.Aliases.SyntaxNode(), "aliases", "Python"))
+	}
+	if opts.CustomTimeouts != nil {
+		// Custom timeouts must be generated as a pulumi.CustomTimeouts object rather than a dict.
+		g.diagnostics = append(g.diagnostics,
+			codegen.UnsupportedOption(opts.CustomTimeouts.SyntaxNode(), "customTimeouts", "Python"))
+	}
+	if opts.AdditionalSecretOutputs != nil {
+		appendOption("additional_secret_outputs", opts.AdditionalSecretOutputs)
+	}
 
 	return block, temps
 }
@@ -280,6 +280,9 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgenf(w, "%.16v.split(%.v)", expr.Args[1], expr.Args[0])
 	case "toJSON":
 		g.Fgenf(w, "json.dumps(%.v)", expr.Args[0])
+	case "can", "try":
+		// try and can are implemented by recovering from runtime errors, which is only supported by the Go generator.
+		g.genNYI(w, "call %v", expr.Name)
 	default:
 		var rng hcl.Range
 		if expr.Syntax != nil {
@@ -0,0 +1,215 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// CoercePropertyValue attempts to coerce the given property value to the given schema type. This allows callers that
+// read hand-edited or externally-produced property maps to be lenient about minor type mismatches while still
+// catching values that cannot safely be interpreted as the expected type. It is defined here rather than as a method
+// of resource.PropertyValue because the SDK's resource package cannot depend on schema types.
+//
+// The coercion rules are:
+//
+// - null, computed, and output values are returned unchanged, as are values of type any or JSON.
+// - secret values are coerced element-wise and remain secret.
+// - a string is coerced to a number if it parses as a finite decimal number, and to an integer if it parses as an
+//   integer.
+// - a number is coerced to a string using its shortest exact decimal representation.
+// - a number is coerced to an integer only if it has no fractional part and is within the 32-bit integer range.
+// - a non-array value is coerced to an array by coercing it to the element type and wrapping it in a single-element
+//   array.
+// - a single-element array is coerced to a non-array type by coercing its only element.
+// - arrays, maps, and objects are coerced element-wise. Object properties that are not described by the schema are
+//   returned unchanged.
+// - a union is coerced to the first element type that the value already conforms to, or failing that to the first
+//   element type to which the value can be coerced.
+// - a token type is coerced to its underlying type, if any.
+//
+// Any other combination is considered unsafe and results in an error.
+func CoercePropertyValue(value resource.PropertyValue, t Type) (resource.PropertyValue, error) {
+	switch {
+	case value.IsNull() || value.IsComputed() || value.IsOutput():
+		return value, nil
+	case value.IsSecret():
+		element, err := CoercePropertyValue(value.SecretValue().Element, t)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return resource.MakeSecret(element), nil
+	}
+
+	switch t := t.(type) {
+	case *TokenType:
+		if t.UnderlyingType == nil {
+			return value, nil
+		}
+		return CoercePropertyValue(value, t.UnderlyingType)
+	case *UnionType:
+		return coerceUnion(value, t)
+	case *ArrayType:
+		if !value.IsArray() {
+			element, err := CoercePropertyValue(value, t.ElementType)
+			if err != nil {
+				return resource.PropertyValue{}, err
+			}
+			return resource.NewArrayProperty([]resource.PropertyValue{element}), nil
+		}
+
+		arr := value.ArrayValue()
+		coerced := make([]resource.PropertyValue, len(arr))
+		for i, v := range arr {
+			element, err := CoercePropertyValue(v, t.ElementType)
+			if err != nil {
+				return resource.PropertyValue{}, errors.Wrapf(err, "element %d", i)
+			}
+			coerced[i] = element
+		}
+		return resource.NewArrayProperty(coerced), nil
+	case *MapType:
+		if !value.IsObject() {
+			return coerceSingleElementArray(value, t)
+		}
+
+		obj := value.ObjectValue()
+		coerced := make(resource.PropertyMap, len(obj))
+		for k, v := range obj {
+			element, err := CoercePropertyValue(v, t.ElementType)
+			if err != nil {
+				return resource.PropertyValue{}, errors.Wrapf(err, "property %q", k)
+			}
+			coerced[k] = element
+		}
+		return resource.NewObjectProperty(coerced), nil
+	case *ObjectType:
+		if !value.IsObject() {
+			return coerceSingleElementArray(value, t)
+		}
+
+		obj := value.ObjectValue()
+		coerced := make(resource.PropertyMap, len(obj))
+		for k, v := range obj {
+			if p, ok := t.Property(string(k)); ok {
+				element, err := CoercePropertyValue(v, p.Type)
+				if err != nil {
+					return resource.PropertyValue{}, errors.Wrapf(err, "property %q", k)
+				}
+				v = element
+			}
+			coerced[k] = v
+		}
+		return resource.NewObjectProperty(coerced), nil
+	}
+
+	switch t {
+	case AnyType, JSONType:
+		return value, nil
+	case BoolType:
+		if value.IsBool() {
+			return value, nil
+		}
+	case IntType:
+		switch {
+		case value.IsNumber():
+			if v := value.NumberValue(); v == math.Trunc(v) && v >= math.MinInt32 && v <= math.MaxInt32 {
+				return value, nil
+			}
+		case value.IsString():
+			if v, err := strconv.ParseInt(value.StringValue(), 10, 32); err == nil {
+				return resource.NewNumberProperty(float64(v)), nil
+			}
+		}
+	case NumberType:
+		switch {
+		case value.IsNumber():
+			return value, nil
+		case value.IsString():
+			if v, ok := parseDecimal(value.StringValue()); ok {
+				return resource.NewNumberProperty(v), nil
+			}
+		}
+	case StringType:
+		switch {
+		case value.IsString():
+			return value, nil
+		case value.IsNumber():
+			return resource.NewStringProperty(strconv.FormatFloat(value.NumberValue(), 'f', -1, 64)), nil
+		}
+	case AssetType:
+		if value.IsAsset() {
+			return value, nil
+		}
+	case ArchiveType:
+		if value.IsArchive() {
+			return value, nil
+		}
+	}
+
+	return coerceSingleElementArray(value, t)
+}
+
+// coerceSingleElementArray coerces a single-element array to the given non-array type by coercing its only element.
+// Any other value cannot be coerced, and an error is returned.
+func coerceSingleElementArray(value resource.PropertyValue, t Type) (resource.PropertyValue, error) {
+	if value.IsArray() && len(value.ArrayValue()) == 1 {
+		return CoercePropertyValue(value.ArrayValue()[0], t)
+	}
+	return resource.PropertyValue{}, errors.Errorf("cannot coerce %v value to type %v", value.TypeString(), t)
+}
+
+// coerceUnion coerces a value to a union type. If the value already conforms to one of the union's element types, it
+// is returned unchanged. Otherwise, the value is coerced to the first element type that accepts it.
+func coerceUnion(value resource.PropertyValue, t *UnionType) (resource.PropertyValue, error) {
+	var first *resource.PropertyValue
+	for _, elementType := range t.ElementTypes {
+		coerced, err := CoercePropertyValue(value, elementType)
+		if err != nil {
+			continue
+		}
+		if coerced.DeepEquals(value) {
+			return value, nil
+		}
+		if first == nil {
+			first = &coerced
+		}
+	}
+	if first == nil {
+		return resource.PropertyValue{}, errors.Errorf("cannot coerce %v value to type %v", value.TypeString(), t)
+	}
+	return *first, nil
+}
+
+// decimalPattern matches a decimal number with an optional exponent, e.g. `-1.5e3`.
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// parseDecimal parses the given string as a finite decimal number. Unlike strconv.ParseFloat, it rejects NaN,
+// infinities, and hexadecimal floats, none of which a string-typed number is expected to contain.
+func parseDecimal(s string) (float64, bool) {
+	if !decimalPattern.MatchString(s) {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
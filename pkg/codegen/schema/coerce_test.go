@@ -0,0 +1,157 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoercePropertyValue(t *testing.T) {
+	objectType := &ObjectType{
+		Token: "pkg:index:Object",
+		Properties: []*Property{
+			{Name: "count", Type: IntType},
+			{Name: "name", Type: StringType},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		value    resource.PropertyValue
+		typ      Type
+		expected resource.PropertyValue
+	}{
+		{"string to number", resource.NewStringProperty("3.5"), NumberType, resource.NewNumberProperty(3.5)},
+		{"exponent string to number", resource.NewStringProperty("-1.5e3"), NumberType, resource.NewNumberProperty(-1500)},
+		{"string to integer", resource.NewStringProperty("42"), IntType, resource.NewNumberProperty(42)},
+		{"number to string", resource.NewNumberProperty(42), StringType, resource.NewStringProperty("42")},
+		{"fraction to string", resource.NewNumberProperty(0.25), StringType, resource.NewStringProperty("0.25")},
+		{"integral number to integer", resource.NewNumberProperty(7), IntType, resource.NewNumberProperty(7)},
+		{
+			"single value to array",
+			resource.NewStringProperty("a"),
+			&ArrayType{ElementType: StringType},
+			resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("a")}),
+		},
+		{
+			"single-element array to value",
+			resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(1)}),
+			StringType,
+			resource.NewStringProperty("1"),
+		},
+		{
+			"array elements",
+			resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("1")}),
+			&ArrayType{ElementType: NumberType},
+			resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(1)}),
+		},
+		{
+			"map elements",
+			resource.NewObjectProperty(resource.PropertyMap{"a": resource.NewNumberProperty(1)}),
+			&MapType{ElementType: StringType},
+			resource.NewObjectProperty(resource.PropertyMap{"a": resource.NewStringProperty("1")}),
+		},
+		{
+			"object properties",
+			resource.NewObjectProperty(resource.PropertyMap{
+				"count": resource.NewStringProperty("2"),
+				"name":  resource.NewNumberProperty(3),
+				"other": resource.NewNumberProperty(4),
+			}),
+			objectType,
+			resource.NewObjectProperty(resource.PropertyMap{
+				"count": resource.NewNumberProperty(2),
+				"name":  resource.NewStringProperty("3"),
+				"other": resource.NewNumberProperty(4),
+			}),
+		},
+		{
+			"conforming union member",
+			resource.NewStringProperty("1"),
+			&UnionType{ElementTypes: []Type{NumberType, StringType}},
+			resource.NewStringProperty("1"),
+		},
+		{
+			"coerced union member",
+			resource.NewStringProperty("1"),
+			&UnionType{ElementTypes: []Type{BoolType, NumberType}},
+			resource.NewNumberProperty(1),
+		},
+		{
+			"token type",
+			resource.NewNumberProperty(1),
+			&TokenType{Token: "pkg:index:Enum", UnderlyingType: StringType},
+			resource.NewStringProperty("1"),
+		},
+		{
+			"secret",
+			resource.MakeSecret(resource.NewNumberProperty(1)),
+			StringType,
+			resource.MakeSecret(resource.NewStringProperty("1")),
+		},
+		{"null", resource.NewNullProperty(), StringType, resource.NewNullProperty()},
+		{"any", resource.NewBoolProperty(true), AnyType, resource.NewBoolProperty(true)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := CoercePropertyValue(c.value, c.typ)
+			assert.NoError(t, err)
+			assert.True(t, c.expected.DeepEquals(actual), "expected %v, got %v", c.expected, actual)
+		})
+	}
+}
+
+func TestCoercePropertyValueRejectsUnsafe(t *testing.T) {
+	cases := []struct {
+		name  string
+		value resource.PropertyValue
+		typ   Type
+	}{
+		{"non-numeric string to number", resource.NewStringProperty("abc"), NumberType},
+		{"NaN string to number", resource.NewStringProperty("NaN"), NumberType},
+		{"infinite string to number", resource.NewStringProperty("Inf"), NumberType},
+		{"negative infinite string to number", resource.NewStringProperty("-infinity"), NumberType},
+		{"out of range string to number", resource.NewStringProperty("1e400"), NumberType},
+		{"hexadecimal string to number", resource.NewStringProperty("0x1p-2"), NumberType},
+		{"fraction to integer", resource.NewNumberProperty(1.5), IntType},
+		{"out of range integer", resource.NewNumberProperty(1 << 40), IntType},
+		{"bool to string", resource.NewBoolProperty(true), StringType},
+		{"string to bool", resource.NewStringProperty("true"), BoolType},
+		{
+			"multi-element array to value",
+			resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewStringProperty("a"),
+				resource.NewStringProperty("b"),
+			}),
+			StringType,
+		},
+		{"string to map", resource.NewStringProperty("a"), &MapType{ElementType: StringType}},
+		{
+			"bad array element",
+			resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("a")}),
+			&ArrayType{ElementType: NumberType},
+		},
+		{"no union member", resource.NewStringProperty("a"), &UnionType{ElementTypes: []Type{BoolType, NumberType}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := CoercePropertyValue(c.value, c.typ)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -15,12 +15,20 @@
 package codegen
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
 	"sort"
 
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 )
 
@@ -106,3 +114,102 @@ func CleanDir(dirPath string, exclusions StringSet) error {
 
 	return nil
 }
+
+// UnsupportedOption returns an error diagnostic for a resource option that cannot be generated in programs written in
+// the given language. The diagnostic's subject is the option's value, if it has a syntax node.
+func UnsupportedOption(node hclsyntax.Node, option, language string) *hcl.Diagnostic {
+	var subject hcl.Range
+	if node != nil {
+		subject = node.Range()
+	}
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("the %s resource option is not supported in %s programs", option, language),
+		Subject:  &subject,
+	}
+}
+
+// GeneratedManifest is the name of the file in which WriteProgram records the files that it has written, along with
+// a hash of their contents. A file whose contents still match the recorded hash has not been edited since it was
+// generated. The manifest is written to the root of the output directory and may be safely deleted, at the cost of
+// WriteProgram no longer recognizing the files it lists as generated.
+const GeneratedManifest = ".pulumi-generated.json"
+
+// WriteProgram writes the files of a generated program (as returned by the GenerateProgram functions) to the given
+// directory, creating the directory and any subdirectories as necessary. An existing file is only overwritten if force
+// is true, its contents already match the generated contents, or it was written by an earlier call to WriteProgram and
+// has not been edited since; otherwise, no files are written and an error is returned. The written files are recorded
+// in a manifest named GeneratedManifest in dir.
+func WriteProgram(files map[string][]byte, dir string, force bool) error {
+	names := SortedKeys(files)
+
+	manifest, err := readGeneratedManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			existing, err := ioutil.ReadFile(path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return err
+			}
+			if !bytes.Equal(existing, files[name]) && manifest[filepath.ToSlash(name)] != contentHash(existing) {
+				return errors.Errorf("refusing to overwrite existing file %v", path)
+			}
+		}
+	}
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return errors.Wrapf(err, "creating directory for %v", path)
+		}
+		if err := ioutil.WriteFile(path, files[name], 0644); err != nil {
+			return errors.Wrapf(err, "writing %v", path)
+		}
+		manifest[filepath.ToSlash(name)] = contentHash(files[name])
+	}
+	return writeGeneratedManifest(dir, manifest)
+}
+
+// readGeneratedManifest reads the manifest of generated files in the given directory, which maps the path of each
+// file to the hash of its generated contents. If the directory has no manifest, an empty one is returned.
+func readGeneratedManifest(dir string) (map[string]string, error) {
+	path := filepath.Join(dir, GeneratedManifest)
+	manifest := map[string]string{}
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return manifest, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(contents, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "reading %v", path)
+	}
+	return manifest, nil
+}
+
+// writeGeneratedManifest writes the manifest of generated files to the given directory.
+func writeGeneratedManifest(dir string, manifest map[string]string) error {
+	path := filepath.Join(dir, GeneratedManifest)
+	contents, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(path, append(contents, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "writing %v", path)
+	}
+	return nil
+}
+
+// contentHash returns the hex-encoded SHA-256 hash of the given contents.
+func contentHash(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
+}
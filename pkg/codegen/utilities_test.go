@@ -0,0 +1,95 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestWriteProgram(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codegen")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	// Files are written under the directory, which is created if necessary.
+	out := filepath.Join(dir, "out")
+	files := map[string][]byte{
+		"main.go":        []byte("package main\n"),
+		"infra/infra.go": []byte("package infra\n"),
+	}
+	assert.NoError(t, WriteProgram(files, out, false))
+	for name, contents := range files {
+		actual, err := ioutil.ReadFile(filepath.Join(out, name))
+		assert.NoError(t, err)
+		assert.Equal(t, contents, actual)
+	}
+	_, err = os.Stat(filepath.Join(out, GeneratedManifest))
+	assert.NoError(t, err)
+
+	// Rewriting identical contents is allowed.
+	assert.NoError(t, WriteProgram(files, out, false))
+
+	// Overwriting a file with different contents is refused, and nothing is written.
+	err = ioutil.WriteFile(filepath.Join(out, "main.go"), []byte("// edited\n"), 0600)
+	assert.NoError(t, err)
+	files["infra/infra.go"] = []byte("package infra // updated\n")
+	err = WriteProgram(files, out, false)
+	assert.Error(t, err)
+	actual, err := ioutil.ReadFile(filepath.Join(out, "infra", "infra.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package infra\n", string(actual))
+
+	// Unless force is set.
+	assert.NoError(t, WriteProgram(files, out, true))
+	actual, err = ioutil.ReadFile(filepath.Join(out, "main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n", string(actual))
+
+	// Files that were generated and have not been edited since may be overwritten.
+	files["main.go"] = []byte("package main // updated\n")
+	assert.NoError(t, WriteProgram(files, out, false))
+	actual, err = ioutil.ReadFile(filepath.Join(out, "main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package main // updated\n", string(actual))
+
+	// Files that were not generated may not.
+	err = ioutil.WriteFile(filepath.Join(out, "go.mod"), []byte("module example.com/edited\n"), 0600)
+	assert.NoError(t, err)
+	files["go.mod"] = []byte("module main\n")
+	assert.Error(t, WriteProgram(files, out, false))
+}
+
+func TestUnsupportedOption(t *testing.T) {
+	rng := hcl.Range{Filename: "main.pp", Start: hcl.Pos{Line: 3, Column: 3}, End: hcl.Pos{Line: 3, Column: 7}}
+	diag := UnsupportedOption(&hclsyntax.LiteralValueExpr{Val: cty.True, SrcRange: rng}, "retainOnDelete", "C#")
+	assert.Equal(t, hcl.DiagError, diag.Severity)
+	assert.Equal(t, "the retainOnDelete resource option is not supported in C# programs", diag.Summary)
+	assert.Equal(t, rng, *diag.Subject)
+
+	// An option without a syntax node has an empty subject.
+	diag = UnsupportedOption(nil, "aliases", "Python")
+	assert.Equal(t, "the aliases resource option is not supported in Python programs", diag.Summary)
+	assert.Equal(t, hcl.Range{}, *diag.Subject)
+}
@@ -60,7 +60,12 @@ func printStepHeader(b io.StringWriter, step StepEventMetadata) {
 		// show a locked symbol, since we are either newly protecting this resource, or retaining protection.
 		extra = " 🔒"
 	}
-	writeString(b, fmt.Sprintf("%s: (%s)%s\n", string(step.Type), step.Op, extra))
+	op := string(step.Op)
+	if step.Op == deploy.OpDelete && old != nil && old.RetainOnDelete {
+		// the resource is only being removed from the stack, so note that the cloud resource will be retained.
+		op += " (retained)"
+	}
+	writeString(b, fmt.Sprintf("%s: (%s)%s\n", string(step.Type), op, extra))
 }
 
 func GetIndentationString(indent int) string {
@@ -175,6 +180,25 @@ func GetResourcePropertiesSummary(step StepEventMetadata, indent int) string {
 	return b.String()
 }
 
+// GetResourceDependenciesSummary returns a rendering of the resources that the given step's resource depends on, as a
+// sequence of `[dependsOn=urn]` pseudo-properties like those printed by GetResourcePropertiesSummary.
+func GetResourceDependenciesSummary(step StepEventMetadata, indent int) string {
+	state := step.New
+	if state == nil {
+		state = step.Old
+	}
+	if state == nil || state.State == nil {
+		return ""
+	}
+
+	var b bytes.Buffer
+	op := considerSameIfNotCreateOrDelete(step.Op)
+	for _, dep := range state.State.Dependencies {
+		writeWithIndentNoPrefix(&b, indent+1, op, "[dependsOn=%s]\n", dep)
+	}
+	return b.String()
+}
+
 func GetResourcePropertiesDetails(
 	step StepEventMetadata, indent int, planning bool, summary bool, debug bool) string {
 	var b bytes.Buffer
@@ -187,7 +211,7 @@ func GetResourcePropertiesDetails(
 		if len(new.Outputs) > 0 {
 			PrintObject(&b, new.Outputs, planning, indent, step.Op, false, debug)
 		} else {
-			PrintObject(&b, new.Inputs, planning, indent, step.Op, false, debug)
+			printObject(&b, new.Inputs, step.Defaults, planning, indent, step.Op, false, debug)
 		}
 	} else if new == nil && old != nil {
 		// in summary view, we don't have to print out the entire object that is getting deleted.
@@ -205,6 +229,31 @@ func GetResourcePropertiesDetails(
 	return b.String()
 }
 
+// GetResourcePropertiesChanges returns a compact rendering of the changes made to the given step's resource: one line
+// per changed leaf property, titled with the property's path relative to the root of the resource (e.g.
+// `dimensions[2].value: "A" => "B"`). Unchanged structure is omitted entirely. Creates and deletes render nothing, as
+// the step header already describes them.
+func GetResourcePropertiesChanges(step StepEventMetadata, indent int, planning bool, debug bool) string {
+	old, new := step.Old, step.New
+	if old == nil || new == nil {
+		return ""
+	}
+
+	var olds, news resource.PropertyMap
+	var include []resource.PropertyKey
+	if len(new.Outputs) > 0 && step.Op != deploy.OpImport && step.Op != deploy.OpImportReplacement {
+		olds, news = old.Outputs, new.Outputs
+	} else {
+		olds, news, include = old.Inputs, new.Inputs, step.Diffs
+	}
+
+	var b bytes.Buffer
+	if diff := olds.Diff(news, resource.IsInternalPropertyKey); diff != nil {
+		PrintObjectDiffChanges(&b, *diff, include, planning, indent+1, debug)
+	}
+	return b.String()
+}
+
 func maxKey(keys []resource.PropertyKey) int {
 	maxkey := 0
 	for _, k := range keys {
@@ -219,6 +268,20 @@ func PrintObject(
 	b *bytes.Buffer, props resource.PropertyMap, planning bool,
 	indent int, op deploy.StepOp, prefix bool, debug bool) {
 
+	printObject(b, props, nil, planning, indent, op, prefix, debug)
+}
+
+// printObject prints the given object. The values of any properties listed in defaults are annotated to indicate that
+// they were supplied by the resource's provider rather than by the program.
+func printObject(
+	b *bytes.Buffer, props resource.PropertyMap, defaults []resource.PropertyKey, planning bool,
+	indent int, op deploy.StepOp, prefix bool, debug bool) {
+
+	defaulted := make(map[resource.PropertyKey]bool)
+	for _, k := range defaults {
+		defaulted[k] = true
+	}
+
 	// Compute the maximum width of property keys so we can justify everything.
 	keys := props.StableKeys()
 	maxkey := maxKey(keys)
@@ -227,7 +290,19 @@ func PrintObject(
 	for _, k := range keys {
 		if v := props[k]; !resource.IsInternalPropertyKey(k) && shouldPrintPropertyValue(v, planning) {
 			printPropertyTitle(b, string(k), maxkey, indent, op, prefix)
-			printPropertyValue(b, v, planning, indent, op, prefix, debug)
+			if !defaulted[k] {
+				printPropertyValue(b, v, planning, indent, op, prefix, debug)
+				continue
+			}
+
+			// Insert the annotation ahead of the newline that terminates the value.
+			var value bytes.Buffer
+			printPropertyValue(&value, v, planning, indent, op, prefix, debug)
+			s := value.String()
+			newline := strings.LastIndex(s, "\n")
+			writeString(b, s[:newline])
+			writeVerbatim(b, op, " (default)")
+			writeString(b, s[newline:])
 		}
 	}
 }
@@ -590,6 +665,108 @@ func PrintObjectDiff(b *bytes.Buffer, diff resource.ObjectDiff, include []resour
 	}
 }
 
+// propertyChange records a single changed leaf of an object diff.
+type propertyChange struct {
+	path  string                 // the path to the property, relative to the root of the object.
+	op    deploy.StepOp          // the kind of change: OpCreate, OpDelete, or OpUpdate.
+	value resource.PropertyValue // the added or deleted value (for OpCreate and OpDelete).
+	diff  resource.ValueDiff     // the change to the value (for OpUpdate).
+}
+
+// PrintObjectDiffChanges prints only the changed leaves of the given object diff, one per line, each titled with its
+// path relative to the root of the object. If an include set is given, only the properties in the set are considered.
+func PrintObjectDiffChanges(b *bytes.Buffer, diff resource.ObjectDiff, include []resource.PropertyKey,
+	planning bool, indent int, debug bool) {
+
+	contract.Assert(indent > 0)
+
+	var changes []propertyChange
+	collectObjectChanges(nil, diff, include, &changes)
+
+	// Compute the maximum width of the paths so we can justify everything.
+	maxpath := 0
+	for _, c := range changes {
+		if len(c.path) > maxpath {
+			maxpath = len(c.path)
+		}
+	}
+
+	for _, c := range changes {
+		path := c.path
+		titleFunc := func(top deploy.StepOp, prefix bool) {
+			printPropertyTitle(b, path, maxpath, indent, top, prefix)
+		}
+		switch c.op {
+		case deploy.OpCreate:
+			printAdd(b, c.value, titleFunc, planning, indent, debug)
+		case deploy.OpDelete:
+			printDelete(b, c.value, titleFunc, planning, indent, debug)
+		default:
+			printPropertyValueDiff(b, titleFunc, c.diff, planning, indent, false, debug)
+		}
+	}
+}
+
+// collectObjectChanges appends the changed leaves of the given object diff, which is located at the given path, to
+// changes in stable order.
+func collectObjectChanges(path resource.PropertyPath, diff resource.ObjectDiff, include []resource.PropertyKey,
+	changes *[]propertyChange) {
+
+	var includeSet map[resource.PropertyKey]bool
+	if include != nil {
+		includeSet = make(map[resource.PropertyKey]bool)
+		for _, k := range include {
+			includeSet[k] = true
+		}
+	}
+
+	for _, k := range diff.Keys() {
+		if includeSet != nil && !includeSet[k] {
+			continue
+		}
+
+		keyPath := appendPropertyPath(path, string(k))
+		if add, isadd := diff.Adds[k]; isadd {
+			*changes = append(*changes, propertyChange{path: keyPath.String(), op: deploy.OpCreate, value: add})
+		} else if delete, isdelete := diff.Deletes[k]; isdelete {
+			*changes = append(*changes, propertyChange{path: keyPath.String(), op: deploy.OpDelete, value: delete})
+		} else if update, isupdate := diff.Updates[k]; isupdate {
+			collectValueChanges(keyPath, update, changes)
+		}
+	}
+}
+
+// collectValueChanges appends the changed leaves of the given value diff, which is located at the given path, to
+// changes in stable order.
+func collectValueChanges(path resource.PropertyPath, diff resource.ValueDiff, changes *[]propertyChange) {
+	switch {
+	case diff.Array != nil:
+		a := diff.Array
+		for i := 0; i < a.Len(); i++ {
+			elemPath := appendPropertyPath(path, i)
+			if add, isadd := a.Adds[i]; isadd {
+				*changes = append(*changes, propertyChange{path: elemPath.String(), op: deploy.OpCreate, value: add})
+			} else if delete, isdelete := a.Deletes[i]; isdelete {
+				*changes = append(*changes, propertyChange{path: elemPath.String(), op: deploy.OpDelete, value: delete})
+			} else if update, isupdate := a.Updates[i]; isupdate {
+				collectValueChanges(elemPath, update, changes)
+			}
+		}
+	case diff.Object != nil:
+		collectObjectChanges(path, *diff.Object, nil, changes)
+	default:
+		*changes = append(*changes, propertyChange{path: path.String(), op: deploy.OpUpdate, diff: diff})
+	}
+}
+
+// appendPropertyPath returns a copy of the given path with the given key appended. The copy ensures that sibling paths
+// never share a backing array.
+func appendPropertyPath(path resource.PropertyPath, key interface{}) resource.PropertyPath {
+	result := make(resource.PropertyPath, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, key)
+}
+
 func printObjectPropertyDiff(b *bytes.Buffer, key resource.PropertyKey, maxkey int, diff resource.ObjectDiff,
 	planning bool, indent int, summary bool, debug bool) {
 
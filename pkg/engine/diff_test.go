@@ -0,0 +1,98 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func TestCreateDetailsAnnotateDefaults(t *testing.T) {
+	step := StepEventMetadata{
+		Op:  deploy.OpCreate,
+		URN: resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resA"),
+		New: &StepEventStateMetadata{
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+				"actionsEnabled": true,
+				"alarmName":      "alarm",
+			}),
+		},
+		Defaults: []resource.PropertyKey{"actionsEnabled"},
+	}
+
+	details := colors.Never.Colorize(GetResourcePropertiesDetails(step, 0, true, false, false))
+	assert.Contains(t, details, `actionsEnabled: true (default)`+"\n")
+	assert.Contains(t, details, `alarmName     : "alarm"`+"\n")
+	assert.NotContains(t, details, `"alarm" (default)`)
+}
+
+func TestChangesOnlyDetails(t *testing.T) {
+	dimensions := func(value string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{"name": "a", "value": "A"},
+			map[string]interface{}{"name": "b", "value": "B"},
+			map[string]interface{}{"name": "c", "value": value},
+		}
+	}
+
+	step := StepEventMetadata{
+		Op:  deploy.OpUpdate,
+		URN: resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resA"),
+		Old: &StepEventStateMetadata{
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+				"alarmName":  "alarm",
+				"dimensions": dimensions("A"),
+			}),
+		},
+		New: &StepEventStateMetadata{
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+				"alarmName":  "alarm",
+				"dimensions": dimensions("B"),
+			}),
+		},
+	}
+
+	details := colors.Never.Colorize(GetResourcePropertiesChanges(step, 0, true, false))
+	assert.Equal(t, `  ~ dimensions[2].value: "A" => "B"`+"\n", details)
+}
+
+func TestDependenciesSummary(t *testing.T) {
+	step := StepEventMetadata{
+		Op:  deploy.OpCreate,
+		URN: resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resC"),
+		New: &StepEventStateMetadata{
+			State: &resource.State{
+				Dependencies: []resource.URN{
+					"urn:pulumi:stack::project::pkgA:m:typA::resA",
+					"urn:pulumi:stack::project::pkgA:m:typA::resB",
+				},
+			},
+		},
+	}
+
+	summary := colors.Never.Colorize(GetResourceDependenciesSummary(step, 0))
+	assert.Equal(t,
+		"    [dependsOn=urn:pulumi:stack::project::pkgA:m:typA::resA]\n"+
+			"    [dependsOn=urn:pulumi:stack::project::pkgA:m:typA::resB]\n",
+		summary)
+
+	step.New = nil
+	assert.Equal(t, "", GetResourceDependenciesSummary(step, 0))
+}
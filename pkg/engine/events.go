@@ -132,6 +132,8 @@ type SummaryEventPayload struct {
 	Duration        time.Duration     // the duration of the entire update operation (zero values for previews)
 	ResourceChanges ResourceChanges   // count of changed resources, useful for reporting
 	PolicyPacks     map[string]string // {policy-pack: version} for each policy pack applied
+	// the work performed comparing resource inputs while planning, if UpdateOptions.DiffStats was set.
+	DiffStats *deploy.DiffStatsSummary
 }
 
 type ResourceOperationFailedPayload struct {
@@ -162,6 +164,7 @@ type StepEventMetadata struct {
 	Res          *StepEventStateMetadata        // the latest state for the resource that is known (worst case, old).
 	Keys         []resource.PropertyKey         // the keys causing replacement (only for CreateStep and ReplaceStep).
 	Diffs        []resource.PropertyKey         // the keys causing diffs
+	Defaults     []resource.PropertyKey         // the input keys supplied by the provider (only for CreateStep).
 	DetailedDiff map[string]plugin.PropertyDiff // the rich, structured diff
 	Logical      bool                           // true if this step represents a logical operation in the program.
 	Provider     string                         // the provider that performed this step.
@@ -185,6 +188,8 @@ type StepEventStateMetadata struct {
 	Parent resource.URN
 	// true to "protect" this resource (protected resources cannot be deleted).
 	Protect bool
+	// true if deleting this resource should only remove it from the stack.
+	RetainOnDelete bool
 	// the resource's input properties (as specified by the program). Note: because this will cross
 	// over rpc boundaries it will be slightly different than the Inputs found in resource_state.
 	// Specifically, secrets will have been filtered out, and large values (like assets) will be
@@ -293,14 +298,16 @@ func queueEvents(events chan<- Event, buffer chan Event, done chan bool) {
 func makeStepEventMetadata(op deploy.StepOp, step deploy.Step, debug bool) StepEventMetadata {
 	contract.Assert(op == step.Op() || step.Op() == deploy.OpRefresh)
 
-	var keys, diffs []resource.PropertyKey
-	if keyer, hasKeys := step.(interface{ Keys() []resource.PropertyKey }); hasKeys {
-		keys = keyer.Keys()
-	}
+	var diffs []resource.PropertyKey
 	if differ, hasDiffs := step.(interface{ Diffs() []resource.PropertyKey }); hasDiffs {
 		diffs = differ.Diffs()
 	}
 
+	var defaults []resource.PropertyKey
+	if defaulter, hasDefaults := step.(interface{ Defaults() []resource.PropertyKey }); hasDefaults {
+		defaults = defaulter.Defaults()
+	}
+
 	var detailedDiff map[string]plugin.PropertyDiff
 	if detailedDiffer, hasDetailedDiff := step.(interface {
 		DetailedDiff() map[string]plugin.PropertyDiff
@@ -312,8 +319,9 @@ func makeStepEventMetadata(op deploy.StepOp, step deploy.Step, debug bool) StepE
 		Op:           op,
 		URN:          step.URN(),
 		Type:         step.Type(),
-		Keys:         keys,
+		Keys:         step.ReplaceReasons(),
 		Diffs:        diffs,
+		Defaults:     defaults,
 		DetailedDiff: detailedDiff,
 		Old:          makeStepEventStateMetadata(step.Old(), debug),
 		New:          makeStepEventStateMetadata(step.New(), debug),
@@ -329,18 +337,19 @@ func makeStepEventStateMetadata(state *resource.State, debug bool) *StepEventSta
 	}
 
 	return &StepEventStateMetadata{
-		State:      state,
-		Type:       state.Type,
-		URN:        state.URN,
-		Custom:     state.Custom,
-		Delete:     state.Delete,
-		ID:         state.ID,
-		Parent:     state.Parent,
-		Protect:    state.Protect,
-		Inputs:     filterPropertyMap(state.Inputs, debug),
-		Outputs:    filterPropertyMap(state.Outputs, debug),
-		Provider:   state.Provider,
-		InitErrors: state.InitErrors,
+		State:          state,
+		Type:           state.Type,
+		URN:            state.URN,
+		Custom:         state.Custom,
+		Delete:         state.Delete,
+		ID:             state.ID,
+		Parent:         state.Parent,
+		Protect:        state.Protect,
+		RetainOnDelete: state.RetainOnDelete,
+		Inputs:         filterPropertyMap(state.Inputs, debug),
+		Outputs:        filterPropertyMap(state.Outputs, debug),
+		Provider:       state.Provider,
+		InitErrors:     state.InitErrors,
 	}
 }
 
@@ -511,7 +520,8 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 	})
 }
 
-func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, policyPacks map[string]string) {
+func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, policyPacks map[string]string,
+	diffStats *deploy.DiffStatsSummary) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- NewEvent(SummaryEvent, SummaryEventPayload{
@@ -520,11 +530,13 @@ func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, poli
 		Duration:        0,
 		ResourceChanges: resourceChanges,
 		PolicyPacks:     policyPacks,
+		DiffStats:       diffStats,
 	})
 }
 
 func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
-	duration time.Duration, resourceChanges ResourceChanges, policyPacks map[string]string) {
+	duration time.Duration, resourceChanges ResourceChanges, policyPacks map[string]string,
+	diffStats *deploy.DiffStatsSummary) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- NewEvent(SummaryEvent, SummaryEventPayload{
@@ -533,6 +545,7 @@ func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
 		Duration:        duration,
 		ResourceChanges: resourceChanges,
 		PolicyPacks:     policyPacks,
+		DiffStats:       diffStats,
 	})
 }
 
@@ -1063,6 +1063,85 @@ func TestSingleResourceDiffUnavailable(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestDiffStats(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"a": "x",
+		"b": map[string]interface{}{
+			"c": 1,
+			"d": []interface{}{1, 2},
+		},
+	})
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: inputs,
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// Run the initial update.
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	diffStats := func(events []Event) *deploy.DiffStatsSummary {
+		for _, e := range events {
+			if e.Type == SummaryEvent {
+				return e.Payload().(SummaryEventPayload).DiffStats
+			}
+		}
+		assert.Fail(t, "no summary event")
+		return nil
+	}
+
+	// Change the inputs to our resource and run a preview. Without DiffStats, nothing is reported.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{
+		"a": "x",
+		"b": map[string]interface{}{
+			"c": 1,
+			"d": []interface{}{1, 3},
+		},
+		"e": true,
+	})
+	_, res = TestOp(Update).Run(project, p.GetTarget(snap), p.Options, true, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, _ *Journal,
+			events []Event, res result.Result) result.Result {
+
+			assert.Nil(t, diffStats(events))
+			return res
+		})
+	assert.Nil(t, res)
+
+	// With DiffStats, the work done comparing the resource's inputs is summarized. The provider does not diff the
+	// resource, so the engine compares its inputs itself, stopping at the first difference: a, b, b.c, b.d, b.d[0],
+	// and b.d[1] are compared, and b and b.d are recursed into.
+	p.Options.DiffStats = true
+	_, res = TestOp(Update).Run(project, p.GetTarget(snap), p.Options, true, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, _ *Journal,
+			events []Event, res result.Result) result.Result {
+
+			stats := diffStats(events)
+			if assert.NotNil(t, stats) {
+				assert.Equal(t, resURN, stats.Costliest)
+				assert.Equal(t, resource.DiffStats{Comparisons: 6, Recursions: 2}, stats.CostliestStats)
+			}
+			return res
+		})
+	assert.Nil(t, res)
+}
+
 func TestDestroyWithPendingDelete(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
 		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
@@ -4275,6 +4354,52 @@ func TestCustomTimeouts(t *testing.T) {
 	assert.Equal(t, snap.Resources[1].CustomTimeouts.Delete, float64(60))
 }
 
+func TestRetainOnDelete(t *testing.T) {
+	deleteCalled := false
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+
+					deleteCalled = true
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	createResource := true
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if createResource {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+				RetainOnDelete: true,
+			})
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+
+	// Create the resource and check that the option is recorded in the snapshot.
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, "resA", string(snap.Resources[1].URN.Name()))
+	assert.True(t, snap.Resources[1].RetainOnDelete)
+
+	// Remove the resource from the program and check that it is removed from the snapshot without calling the
+	// provider's Delete. The default provider is no longer used, so it is removed as well.
+	createResource = false
+	snap = p.Run(t, snap)
+	assert.False(t, deleteCalled)
+	assert.Len(t, snap.Resources, 0)
+}
+
 func TestProviderDiffMissingOldOutputs(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
 		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
@@ -5936,3 +6061,90 @@ func TestProviderInheritanceGolangLifecycle(t *testing.T) {
 	}
 	p.Run(t, nil)
 }
+
+// boundedTestProviders tracks the provider processes started by a multi-provider test.
+type boundedTestProviders struct {
+	m       sync.Mutex
+	running int
+	maximum int
+	started int
+}
+
+// boundedTestProvider is a test provider that records when it is shut down.
+type boundedTestProvider struct {
+	*deploytest.Provider
+
+	providers *boundedTestProviders
+}
+
+func (p *boundedTestProvider) Close() error {
+	p.providers.m.Lock()
+	defer p.providers.m.Unlock()
+	p.providers.running--
+	return nil
+}
+
+func TestMaxProviders(t *testing.T) {
+	const packages, resourcesPerPackage = 5, 3
+
+	started := &boundedTestProviders{}
+	var loaders []*deploytest.ProviderLoader
+	for i := 0; i < packages; i++ {
+		pkg := tokens.Package(fmt.Sprintf("pkg%d", i))
+		loaders = append(loaders, deploytest.NewProviderLoader(pkg, semver.MustParse("1.0.0"),
+			func() (plugin.Provider, error) {
+				started.m.Lock()
+				defer started.m.Unlock()
+				started.running++
+				started.started++
+				if started.running > started.maximum {
+					started.maximum = started.running
+				}
+				return &boundedTestProvider{Provider: &deploytest.Provider{}, providers: started}, nil
+			}))
+	}
+
+	inputs := resource.PropertyMap{"foo": resource.NewStringProperty("bar")}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		// Register the resources concurrently so that steps against different providers run at the same time.
+		var wg sync.WaitGroup
+		for i := 0; i < packages; i++ {
+			for j := 0; j < resourcesPerPackage; j++ {
+				typ, name := tokens.Type(fmt.Sprintf("pkg%d:m:typA", i)), fmt.Sprintf("res%d-%d", i, j)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, _, _, err := monitor.RegisterResource(typ, name, true, deploytest.ResourceOptions{
+						Inputs: inputs,
+					})
+					assert.NoError(t, err)
+				}()
+			}
+		}
+		wg.Wait()
+		return nil
+	})
+
+	p := &TestPlan{
+		Options: UpdateOptions{Parallel: 10, MaxProviders: 2},
+	}
+	// The test host reads the limit from the plan's options, as the default host reads it from the plugin context.
+	p.Options.host = deploytest.NewPluginHostWithProviderLimit(func() int { return p.Options.MaxProviders },
+		nil, nil, program, loaders...)
+
+	// Create the resources, then update them so that every provider is needed again after it may have been shut down.
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, packages*(resourcesPerPackage+1))
+
+	inputs = resource.PropertyMap{"foo": resource.NewStringProperty("baz")}
+	snap, res = TestOp(Update).Run(project, p.GetTarget(snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, packages*(resourcesPerPackage+1))
+
+	started.m.Lock()
+	defer started.m.Unlock()
+	assert.True(t, started.maximum <= p.Options.MaxProviders, "%d providers ran at once", started.maximum)
+	assert.True(t, started.started > 2*packages, "expected idle providers to be shut down and restarted")
+}
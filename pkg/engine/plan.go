@@ -124,6 +124,8 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	if err != nil {
 		return nil, err
 	}
+	plugctx.MaxProviders = opts.MaxProviders
+	plugctx.DiffStats = opts.DiffStats
 
 	opts.trustDependencies = proj.TrustResourceDependencies()
 	// Now create the state source.  This may issue an error if it can't create the source.  This entails,
@@ -230,7 +232,7 @@ func printPlan(ctx *Context, planResult *planResult, dryRun bool, policies map[s
 
 	// Emit an event with a summary of operation counts.
 	changes := ResourceChanges(actions.Ops)
-	planResult.Options.Events.previewSummaryEvent(changes, policies)
+	planResult.Options.Events.previewSummaryEvent(changes, policies, planResult.Plan.DiffStats())
 
 	if res != nil {
 
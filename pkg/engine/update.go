@@ -107,6 +107,9 @@ type UpdateOptions struct {
 	// the degree of parallelism for resource operations (<=1 for serial).
 	Parallel int
 
+	// the maximum number of resource provider plugins to keep running at once (<=0 for no limit).
+	MaxProviders int
+
 	// true if debugging output it enabled
 	Debug bool
 
@@ -132,6 +135,10 @@ type UpdateOptions struct {
 	// true if the engine should use legacy diffing behavior during an update.
 	UseLegacyDiff bool
 
+	// true if the engine should record the work performed comparing the inputs of each resource while planning, and
+	// report it in the summary event.
+	DiffStats bool
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -446,7 +453,7 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 
 				// Print out the total number of steps performed (and their kinds), the duration, and any summary info.
 				opts.Events.updateSummaryEvent(actions.MaybeCorrupt, time.Since(start),
-					resourceChanges, policies)
+					resourceChanges, policies, planResult.Plan.DiffStats())
 			}
 		}
 	}
@@ -30,6 +30,18 @@ import (
 
 // Print prints a resource graph.
 func Print(g graph.Graph, w io.Writer) error {
+	return printGraph(g, w, nil)
+}
+
+// PrintClustered prints a resource graph, grouping its vertices into clusters so that related vertices are drawn
+// together. The cluster function returns the name of the cluster to which a vertex belongs, which is also used as the
+// cluster's label.
+func PrintClustered(g graph.Graph, w io.Writer, cluster func(v graph.Vertex) string) error {
+	contract.Require(cluster != nil, "cluster")
+	return printGraph(g, w, cluster)
+}
+
+func printGraph(g graph.Graph, w io.Writer, cluster func(v graph.Vertex) string) error {
 	// Allocate a new writer.  In general, we will ignore write errors throughout this function, for simplicity, opting
 	// instead to return the result of flushing the buffer at the end, which is generally latching.
 	b := bufio.NewWriter(w)
@@ -62,6 +74,10 @@ func Print(g graph.Graph, w io.Writer) error {
 		return id
 	}
 
+	// If we are clustering, vertices are collected by cluster and emitted once all edges have been printed.
+	var clusterNames []string
+	clusters := make(map[string][]string)
+
 	// Now, until the frontier is empty, emit entries into the stream.
 	indent := "    "
 	emitted := make(map[graph.Vertex]bool)
@@ -77,15 +93,24 @@ func Print(g graph.Graph, w io.Writer) error {
 
 		// Print this vertex; first its "label" (type) and then its direct dependencies.
 		// IDEA: consider serializing properties on the node also.
-		if _, err := b.WriteString(fmt.Sprintf("%v%v", indent, id)); err != nil {
-			return err
-		}
+		decl := id
+		var attrs []string
 		if label := v.Label(); label != "" {
-			if _, err := b.WriteString(fmt.Sprintf(" [label=\"%v\"]", label)); err != nil {
-				return err
-			}
+			attrs = append(attrs, fmt.Sprintf("label=\"%v\"", label))
 		}
-		if _, err := b.WriteString(";\n"); err != nil {
+		if cv, ok := v.(graph.ColoredVertex); ok && cv.Color() != "" {
+			attrs = append(attrs, fmt.Sprintf("color=\"%v\"", cv.Color()))
+		}
+		if len(attrs) > 0 {
+			decl += fmt.Sprintf(" [%s]", strings.Join(attrs, ", "))
+		}
+		if cluster != nil {
+			name := cluster(v)
+			if _, has := clusters[name]; !has {
+				clusterNames = append(clusterNames, name)
+			}
+			clusters[name] = append(clusters[name], decl)
+		} else if _, err := b.WriteString(fmt.Sprintf("%v%v;\n", indent, decl)); err != nil {
 			return err
 		}
 
@@ -125,6 +150,24 @@ func Print(g graph.Graph, w io.Writer) error {
 		}
 	}
 
+	// Print the clusters, if any, each as a subgraph containing its vertices.
+	for i, name := range clusterNames {
+		if _, err := b.WriteString(fmt.Sprintf("%vsubgraph cluster_%d {\n", indent, i)); err != nil {
+			return err
+		}
+		if _, err := b.WriteString(fmt.Sprintf("%v%vlabel=\"%v\";\n", indent, indent, name)); err != nil {
+			return err
+		}
+		for _, decl := range clusters[name] {
+			if _, err := b.WriteString(fmt.Sprintf("%v%v%v;\n", indent, indent, decl)); err != nil {
+				return err
+			}
+		}
+		if _, err := b.WriteString(fmt.Sprintf("%v}\n", indent)); err != nil {
+			return err
+		}
+	}
+
 	// Finish the graph.
 	if _, err := b.WriteString("}\n"); err != nil {
 		return err
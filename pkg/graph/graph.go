@@ -38,6 +38,13 @@ type Vertex interface {
 	Outs() []Edge      // outgoing edges from this vertex to other vertices within the graph.
 }
 
+// ColoredVertex is a vertex that has a color, for when its graph is displayed.  Vertices that do not implement it are
+// displayed without one.
+type ColoredVertex interface {
+	Vertex
+	Color() string // an optional color for this vertex.
+}
+
 // Edge is a directed edge from one vertex to another.
 type Edge interface {
 	Data() interface{} // arbitrary data associated with this edge.
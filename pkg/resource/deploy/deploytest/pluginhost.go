@@ -62,6 +62,7 @@ type pluginHost struct {
 	statusSink      diag.Sink
 
 	providers map[plugin.Provider]struct{}
+	pool      *plugin.ProviderPool // bounds the number of running providers, if non-nil.
 	closed    bool
 	m         sync.Mutex
 }
@@ -78,6 +79,17 @@ func NewPluginHost(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRu
 	}
 }
 
+// NewPluginHostWithProviderLimit returns a plugin host like NewPluginHost that, like the default plugin host, runs at
+// most limit() providers at once (<=0 for no limit). Idle providers are shut down to stay within the limit and
+// restarted when they are next used.
+func NewPluginHostWithProviderLimit(limit func() int, sink, statusSink diag.Sink,
+	languageRuntime plugin.LanguageRuntime, providerLoaders ...*ProviderLoader) plugin.Host {
+
+	host := NewPluginHost(sink, statusSink, languageRuntime, providerLoaders...).(*pluginHost)
+	host.pool = plugin.NewProviderPool(limit)
+	return host
+}
+
 func (host *pluginHost) isClosed() bool {
 	host.m.Lock()
 	defer host.m.Unlock()
@@ -111,9 +123,15 @@ func (host *pluginHost) Provider(pkg tokens.Package, version *semver.Version) (p
 		}
 	}
 
-	prov, err := load()
-	if err != nil {
-		return nil, err
+	var prov plugin.Provider
+	if host.pool != nil {
+		prov = host.pool.NewProvider(pkg, load)
+	} else {
+		p, err := load()
+		if err != nil {
+			return nil, err
+		}
+		prov = p
 	}
 
 	host.m.Lock()
@@ -167,6 +185,11 @@ func (host *pluginHost) CloseProvider(provider plugin.Provider) error {
 	defer host.m.Unlock()
 
 	delete(host.providers, provider)
+
+	// Pooled providers must be closed in order to free their slots in the pool.
+	if host.pool != nil {
+		return provider.Close()
+	}
 	return nil
 }
 func (host *pluginHost) ListPlugins() []workspace.PluginInfo {
@@ -29,6 +29,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
 )
 
@@ -117,6 +118,16 @@ type Plan struct {
 	preview              bool                             // true if this plan is to be previewed rather than applied.
 	depGraph             *graph.DependencyGraph           // the dependency graph of the old snapshot
 	providers            *providers.Registry              // the provider registry for this plan.
+	diffStats            *DiffStatsSummary                // the work performed comparing inputs, if requested.
+}
+
+// DiffStatsSummary summarizes the work that the planner performed comparing the old and new inputs of resources. It
+// is only recorded if the plugin context's DiffStats flag is set.
+type DiffStatsSummary struct {
+	Resources      int                // the number of resources whose inputs were compared.
+	Total          resource.DiffStats // the work performed comparing the inputs of every resource.
+	Costliest      resource.URN       // the resource whose inputs took the most comparisons, if any.
+	CostliestStats resource.DiffStats // the work performed comparing the inputs of the costliest resource.
 }
 
 // addDefaultProviders adds any necessary default provider definitions and references to the given snapshot. Version
@@ -269,7 +280,7 @@ func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
 		return nil, err
 	}
 
-	return &Plan{
+	plan := &Plan{
 		ctx:                  ctx,
 		target:               target,
 		prev:                 prev,
@@ -279,7 +290,11 @@ func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
 		preview:              preview,
 		depGraph:             depGraph,
 		providers:            reg,
-	}, nil
+	}
+	if ctx.DiffStats {
+		plan.diffStats = &DiffStatsSummary{}
+	}
+	return plan, nil
 }
 
 func (p *Plan) Ctx() *plugin.Context                   { return p.ctx }
@@ -289,6 +304,24 @@ func (p *Plan) Prev() *Snapshot                        { return p.prev }
 func (p *Plan) Olds() map[resource.URN]*resource.State { return p.olds }
 func (p *Plan) Source() Source                         { return p.source }
 
+// DiffStats returns a summary of the work that the planner performed comparing resource inputs, or nil if the plugin
+// context's DiffStats flag is not set.
+func (p *Plan) DiffStats() *DiffStatsSummary {
+	return p.diffStats
+}
+
+// recordDiffStats records the work that the planner performed comparing the inputs of the given resource.
+func (p *Plan) recordDiffStats(urn resource.URN, stats resource.DiffStats) {
+	logging.V(7).Infof("diff of %v: %d comparisons, %d recursions", urn, stats.Comparisons, stats.Recursions)
+
+	p.diffStats.Resources++
+	p.diffStats.Total.Comparisons += stats.Comparisons
+	p.diffStats.Total.Recursions += stats.Recursions
+	if stats.Comparisons > p.diffStats.CostliestStats.Comparisons {
+		p.diffStats.Costliest, p.diffStats.CostliestStats = urn, stats
+	}
+}
+
 func (p *Plan) GetProvider(ref providers.Reference) (plugin.Provider, bool) {
 	return p.providers.GetProvider(ref)
 }
@@ -130,70 +130,123 @@ func (snap *Snapshot) NormalizeURNReferences() error {
 //  5. For every URN in the snapshot, there must be at most one resource with that URN that is not pending deletion
 //  6. The magic manifest number should change every time the snapshot is mutated
 func (snap *Snapshot) VerifyIntegrity() error {
-	if snap != nil {
-		// Ensure the magic cookie checks out.
-		if snap.Manifest.Magic != snap.Manifest.NewMagic() {
-			return errors.Errorf("magic cookie mismatch; possible tampering/corruption detected")
-		}
+	if errs := snap.Verify(); len(errs) != 0 {
+		return errs[0]
+	}
+	return nil
+}
 
-		// Now check the resources.  For now, we just verify that parents come before children, and that there aren't
-		// any duplicate URNs.
-		urns := make(map[resource.URN]*resource.State)
-		provs := make(map[providers.Reference]struct{})
-		for i, state := range snap.Resources {
-			urn := state.URN
-
-			if providers.IsProviderType(state.Type) {
-				ref, err := providers.NewReference(urn, state.ID)
-				if err != nil {
-					return errors.Errorf("provider %s is not referenceable: %v", urn, err)
-				}
+// The names of the integrity checks performed by Verify.
+const (
+	IntegrityCheckMagic      = "magic"      // the manifest's magic cookie matches its contents.
+	IntegrityCheckProvider   = "provider"   // providers are referenceable and referenced providers exist.
+	IntegrityCheckParent     = "parent"     // parents exist and come before their children.
+	IntegrityCheckDependency = "dependency" // dependencies exist and come before their dependents.
+	IntegrityCheckDuplicate  = "duplicate"  // URNs are unique, save for resources that are pending deletion.
+)
+
+// IntegrityError describes a single problem found by Verify.
+type IntegrityError struct {
+	URN     resource.URN // the URN of the offending resource, if any.
+	Check   string       // the name of the check that failed.
+	Message string       // a description of the problem.
+}
+
+func (e *IntegrityError) Error() string {
+	return e.Message
+}
+
+func newIntegrityError(urn resource.URN, check, format string, args ...interface{}) *IntegrityError {
+	return &IntegrityError{URN: urn, Check: check, Message: fmt.Sprintf(format, args...)}
+}
+
+// Verify runs all of the structural integrity checks that VerifyIntegrity runs, but rather than stopping at the first
+// problem, it returns every problem it finds as an *IntegrityError.
+func (snap *Snapshot) Verify() []error {
+	if snap == nil {
+		return nil
+	}
+
+	var errs []error
+
+	// Ensure the magic cookie checks out.
+	if snap.Manifest.Magic != snap.Manifest.NewMagic() {
+		errs = append(errs, newIntegrityError("", IntegrityCheckMagic,
+			"magic cookie mismatch; possible tampering/corruption detected"))
+	}
+
+	// Now check the resources.  For now, we just verify that parents come before children, and that there aren't
+	// any duplicate URNs.
+	urns := make(map[resource.URN]*resource.State)
+	provs := make(map[providers.Reference]struct{})
+	for i, state := range snap.Resources {
+		urn := state.URN
+
+		if providers.IsProviderType(state.Type) {
+			ref, err := providers.NewReference(urn, state.ID)
+			if err != nil {
+				errs = append(errs, newIntegrityError(urn, IntegrityCheckProvider,
+					"provider %s is not referenceable: %v", urn, err))
+			} else {
 				provs[ref] = struct{}{}
 			}
-			if provider := state.Provider; provider != "" {
-				ref, err := providers.ParseReference(provider)
-				if err != nil {
-					return errors.Errorf("failed to parse provider reference for resource %s: %v", urn, err)
-				}
-				if _, has := provs[ref]; !has {
-					return errors.Errorf("resource %s refers to unknown provider %s", urn, ref)
-				}
+		}
+		if provider := state.Provider; provider != "" {
+			ref, err := providers.ParseReference(provider)
+			if err != nil {
+				errs = append(errs, newIntegrityError(urn, IntegrityCheckProvider,
+					"failed to parse provider reference for resource %s: %v", urn, err))
+			} else if _, has := provs[ref]; !has {
+				errs = append(errs, newIntegrityError(urn, IntegrityCheckProvider,
+					"resource %s refers to unknown provider %s", urn, ref))
 			}
+		}
 
-			if par := state.Parent; par != "" {
-				if _, has := urns[par]; !has {
-					// The parent isn't there; to give a good error message, see whether it's missing entirely, or
-					// whether it comes later in the snapshot (neither of which should ever happen).
-					for _, other := range snap.Resources[i+1:] {
-						if other.URN == par {
-							return errors.Errorf("child resource %s's parent %s comes after it", urn, par)
-						}
-					}
-					return errors.Errorf("child resource %s refers to missing parent %s", urn, par)
+		if par := state.Parent; par != "" {
+			if _, has := urns[par]; !has {
+				// The parent isn't there; to give a good error message, see whether it's missing entirely, or
+				// whether it comes later in the snapshot (neither of which should ever happen).
+				if comesAfter(snap.Resources[i+1:], par) {
+					errs = append(errs, newIntegrityError(urn, IntegrityCheckParent,
+						"child resource %s's parent %s comes after it", urn, par))
+				} else {
+					errs = append(errs, newIntegrityError(urn, IntegrityCheckParent,
+						"child resource %s refers to missing parent %s", urn, par))
 				}
 			}
+		}
 
-			for _, dep := range state.Dependencies {
-				if _, has := urns[dep]; !has {
-					// same as above - doing this for better error messages
-					for _, other := range snap.Resources[i+1:] {
-						if other.URN == dep {
-							return errors.Errorf("resource %s's dependency %s comes after it", urn, other.URN)
-						}
-					}
-
-					return errors.Errorf("resource %s dependency %s refers to missing resource", urn, dep)
+		for _, dep := range state.Dependencies {
+			if _, has := urns[dep]; !has {
+				// same as above - doing this for better error messages
+				if comesAfter(snap.Resources[i+1:], dep) {
+					errs = append(errs, newIntegrityError(urn, IntegrityCheckDependency,
+						"resource %s's dependency %s comes after it", urn, dep))
+				} else {
+					errs = append(errs, newIntegrityError(urn, IntegrityCheckDependency,
+						"resource %s dependency %s refers to missing resource", urn, dep))
 				}
 			}
+		}
 
-			if _, has := urns[urn]; has && !state.Delete {
-				// The only time we should have duplicate URNs is when all but one of them are marked for deletion.
-				return errors.Errorf("duplicate resource %s (not marked for deletion)", urn)
-			}
-
-			urns[urn] = state
+		if _, has := urns[urn]; has && !state.Delete {
+			// The only time we should have duplicate URNs is when all but one of them are marked for deletion.
+			errs = append(errs, newIntegrityError(urn, IntegrityCheckDuplicate,
+				"duplicate resource %s (not marked for deletion)", urn))
 		}
+
+		urns[urn] = state
 	}
 
-	return nil
+	return errs
+}
+
+// comesAfter returns true if any of the given resources has the given URN.
+func comesAfter(rest []*resource.State, urn resource.URN) bool {
+	for _, other := range rest {
+		if other.URN == urn {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,61 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotVerify(t *testing.T) {
+	resourceA := newResource("a")
+	resourceB := newResource("b")
+	resourceC := newResource("c")
+
+	// A's parent is missing, C depends on D, which comes after it, and A is duplicated.
+	resourceA.Parent = newResource("missing").URN
+	duplicateA := newResource("a")
+	resourceD := newResource("d")
+	resourceC.Dependencies = []resource.URN{resourceD.URN}
+
+	// The manifest's magic cookie is never computed, so it does not match. The version is set explicitly because an
+	// unversioned manifest has no magic cookie to check.
+	snap := newSnapshot([]*resource.State{resourceA, resourceB, duplicateA, resourceC, resourceD}, nil)
+	snap.Manifest.Version = "1.0.0"
+
+	errs := snap.Verify()
+	if !assert.Len(t, errs, 4) {
+		t.FailNow()
+	}
+
+	type check struct {
+		urn   resource.URN
+		check string
+	}
+	var checks []check
+	for _, err := range errs {
+		integrityErr, ok := err.(*IntegrityError)
+		if !assert.True(t, ok) {
+			t.FailNow()
+		}
+		checks = append(checks, check{urn: integrityErr.URN, check: integrityErr.Check})
+	}
+	assert.Equal(t, []check{
+		{urn: "", check: IntegrityCheckMagic},
+		{urn: resourceA.URN, check: IntegrityCheckParent},
+		{urn: resourceA.URN, check: IntegrityCheckDuplicate},
+		{urn: resourceC.URN, check: IntegrityCheckDependency},
+	}, checks)
+	assert.Contains(t, errs[3].Error(), "comes after it")
+
+	// VerifyIntegrity reports the first problem.
+	assert.Equal(t, errs[0], snap.VerifyIntegrity())
+
+	// Once the problems are fixed, there is nothing to report.
+	resourceA.Parent = ""
+	resourceC.Dependencies = nil
+	snap = newSnapshot([]*resource.State{resourceA, resourceB, resourceC, resourceD}, nil)
+	snap.Manifest.Magic = snap.Manifest.NewMagic()
+	assert.Len(t, snap.Verify(), 0)
+	assert.NoError(t, snap.VerifyIntegrity())
+}
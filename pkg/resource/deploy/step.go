@@ -53,6 +53,18 @@ type Step interface {
 	Res() *resource.State // the latest state for the resource that is known (worst case, old).
 	Logical() bool        // true if this step represents a logical operation in the program.
 	Plan() *Plan          // the owning plan.
+
+	// ReplaceReasons returns the keys of the properties that caused this step to replace its resource. The result is
+	// empty, but not nil, for steps that do not replace a resource.
+	ReplaceReasons() []resource.PropertyKey
+}
+
+// replaceReasons returns the given replacement keys, or an empty list if there are none.
+func replaceReasons(keys []resource.PropertyKey) []resource.PropertyKey {
+	if keys == nil {
+		return []resource.PropertyKey{}
+	}
+	return keys
 }
 
 // SameStep is a mutating step that does nothing.
@@ -120,6 +132,10 @@ func (s *SameStep) New() *resource.State { return s.new }
 func (s *SameStep) Res() *resource.State { return s.new }
 func (s *SameStep) Logical() bool        { return true }
 
+func (s *SameStep) ReplaceReasons() []resource.PropertyKey {
+	return []resource.PropertyKey{}
+}
+
 func (s *SameStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// Retain the ID, and outputs:
 	s.new.ID = s.old.ID
@@ -207,6 +223,27 @@ func (s *CreateStep) Diffs() []resource.PropertyKey                { return s.di
 func (s *CreateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 func (s *CreateStep) Logical() bool                                { return !s.replacing }
 
+func (s *CreateStep) ReplaceReasons() []resource.PropertyKey {
+	return replaceReasons(s.keys)
+}
+
+// Defaults returns the keys of the top-level input properties that were not specified by the program, and were
+// therefore supplied by the provider when it checked the resource's inputs.
+func (s *CreateStep) Defaults() []resource.PropertyKey {
+	goal := s.reg.Goal()
+	if goal == nil {
+		return nil
+	}
+
+	var defaults []resource.PropertyKey
+	for _, k := range s.new.Inputs.StableKeys() {
+		if _, has := goal.Properties[k]; !has && !resource.IsInternalPropertyKey(k) {
+			defaults = append(defaults, k)
+		}
+	}
+	return defaults
+}
+
 func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	var resourceError error
 	resourceStatus := resource.StatusOK
@@ -324,6 +361,10 @@ func (s *DeleteStep) New() *resource.State { return nil }
 func (s *DeleteStep) Res() *resource.State { return s.old }
 func (s *DeleteStep) Logical() bool        { return !s.replacing }
 
+func (s *DeleteStep) ReplaceReasons() []resource.PropertyKey {
+	return []resource.PropertyKey{}
+}
+
 func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// Refuse to delete protected resources.
 	if s.old.Protect {
@@ -331,8 +372,9 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			errors.Errorf("refusing to delete protected resource '%s'", s.old.URN)
 	}
 
-	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle.
-	if !preview && !s.old.External {
+	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle. Likewise, a resource that is
+	// marked as RetainOnDelete is only removed from the stack; the cloud resource is left intact.
+	if !preview && !s.old.External && !s.old.RetainOnDelete {
 		if s.old.Custom {
 			// Invoke the Delete RPC function for this provider:
 			prov, err := getProvider(s)
@@ -375,6 +417,10 @@ func (s *RemovePendingReplaceStep) New() *resource.State { return nil }
 func (s *RemovePendingReplaceStep) Res() *resource.State { return s.old }
 func (s *RemovePendingReplaceStep) Logical() bool        { return false }
 
+func (s *RemovePendingReplaceStep) ReplaceReasons() []resource.PropertyKey {
+	return []resource.PropertyKey{}
+}
+
 func (s *RemovePendingReplaceStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	return resource.StatusOK, nil, nil
 }
@@ -432,6 +478,10 @@ func (s *UpdateStep) Logical() bool                                { return true
 func (s *UpdateStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *UpdateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 
+func (s *UpdateStep) ReplaceReasons() []resource.PropertyKey {
+	return []resource.PropertyKey{}
+}
+
 func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// Always propagate the ID, even in previews and refreshes.
 	s.new.ID = s.old.ID
@@ -526,6 +576,10 @@ func (s *ReplaceStep) Diffs() []resource.PropertyKey                { return s.d
 func (s *ReplaceStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 func (s *ReplaceStep) Logical() bool                                { return true }
 
+func (s *ReplaceStep) ReplaceReasons() []resource.PropertyKey {
+	return replaceReasons(s.keys)
+}
+
 func (s *ReplaceStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// If this is a pending delete, we should have marked the old resource for deletion in the CreateReplacement step.
 	contract.Assert(!s.pendingDelete || s.old.Delete)
@@ -604,6 +658,10 @@ func (s *ReadStep) New() *resource.State { return s.new }
 func (s *ReadStep) Res() *resource.State { return s.new }
 func (s *ReadStep) Logical() bool        { return !s.replacing }
 
+func (s *ReadStep) ReplaceReasons() []resource.PropertyKey {
+	return []resource.PropertyKey{}
+}
+
 func (s *ReadStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	urn := s.new.URN
 	id := s.new.ID
@@ -691,6 +749,10 @@ func (s *RefreshStep) New() *resource.State { return s.new }
 func (s *RefreshStep) Res() *resource.State { return s.old }
 func (s *RefreshStep) Logical() bool        { return false }
 
+func (s *RefreshStep) ReplaceReasons() []resource.PropertyKey {
+	return []resource.PropertyKey{}
+}
+
 // ResultOp returns the operation that corresponds to the change to this resource after reading its current state, if
 // any.
 func (s *RefreshStep) ResultOp() StepOp {
@@ -761,7 +823,7 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 		s.new = resource.NewState(s.old.Type, s.old.URN, s.old.Custom, s.old.Delete, resourceID, inputs, outputs,
 			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
 			s.old.PropertyDependencies, s.old.PendingReplacement, s.old.AdditionalSecretOutputs, s.old.Aliases,
-			&s.old.CustomTimeouts, s.old.ImportID)
+			&s.old.CustomTimeouts, s.old.ImportID, s.old.RetainOnDelete)
 	} else {
 		s.new = nil
 	}
@@ -836,6 +898,10 @@ func (s *ImportStep) Logical() bool                                { return !s.r
 func (s *ImportStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *ImportStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 
+func (s *ImportStep) ReplaceReasons() []resource.PropertyKey {
+	return []resource.PropertyKey{}
+}
+
 func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	complete := func() { s.reg.Done(&RegisterResult{State: s.new}) }
 
@@ -871,7 +937,7 @@ func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	// differences between the old and new states are between the inputs and outputs.
 	s.old = resource.NewState(s.new.Type, s.new.URN, s.new.Custom, false, s.new.ID, read.Inputs, read.Outputs,
 		s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
-		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID)
+		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID, s.new.RetainOnDelete)
 
 	// Check the user inputs using the provider inputs for defaults.
 	inputs, failures, err := prov.Check(s.new.URN, s.old.Inputs, s.new.Inputs, preview)
@@ -885,7 +951,7 @@ func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 
 	// Diff the user inputs against the provider inputs. If there are any differences, fail the import.
 	diff, err := diffResource(s.new.URN, s.new.ID, s.old.Inputs, s.old.Outputs, s.new.Inputs, prov, preview,
-		s.ignoreChanges)
+		s.ignoreChanges, nil)
 	if err != nil {
 		return rst, nil, err
 	}
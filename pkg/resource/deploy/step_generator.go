@@ -107,9 +107,10 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, res
 		nil,   /* propertyDependencies */
 		false, /* deleteBeforeCreate */
 		event.AdditionalSecretOutputs(),
-		nil, /* aliases */
-		nil, /* customTimeouts */
-		"",  /* importID */
+		nil,   /* aliases */
+		nil,   /* customTimeouts */
+		"",    /* importID */
+		false, /* retainOnDelete */
 	)
 	old, hasOld := sg.plan.Olds()[urn]
 
@@ -251,7 +252,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	// get serialized into the checkpoint file.
 	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
 		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
-		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts, "")
+		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts, "", goal.RetainOnDelete)
 
 	// Mark the URN/resource as having been seen. So we can run analyzers on all resources seen, as well as
 	// lookup providers for calculating replacement of resources that use the provider.
@@ -1035,28 +1036,46 @@ func (sg *stepGenerator) diff(urn resource.URN, old, new *resource.State, oldInp
 		return plugin.DiffResult{Changes: plugin.DiffSome, ReplaceKeys: []resource.PropertyKey{"provider"}}, nil
 	}
 
+	// If requested, record the work that the planner's own comparisons of the resource's inputs perform. Diffs that
+	// are computed by the resource's provider happen out of process, and are not counted.
+	var stats *resource.DiffStats
+	if sg.plan.ctx.DiffStats {
+		stats = &resource.DiffStats{}
+		defer func() { sg.plan.recordDiffStats(urn, *stats) }()
+	}
+
 	// Apply legacy diffing behavior if requested. In this mode, if the provider-calculated inputs for a resource did
 	// not change, then the resource is considered to have no diff between its desired and actual state.
-	if sg.opts.UseLegacyDiff && oldInputs.DeepEquals(newInputs) {
+	if sg.opts.UseLegacyDiff && inputsEqual(oldInputs, newInputs, stats) {
 		return plugin.DiffResult{Changes: plugin.DiffNone}, nil
 	}
 
 	// If there is no provider for this resource (which should only happen for component resources), simply return a
 	// "diffs exist" result.
 	if prov == nil {
-		if oldInputs.DeepEquals(newInputs) {
+		if inputsEqual(oldInputs, newInputs, stats) {
 			return plugin.DiffResult{Changes: plugin.DiffNone}, nil
 		}
 		return plugin.DiffResult{Changes: plugin.DiffSome}, nil
 	}
 
-	return diffResource(urn, old.ID, oldInputs, oldOutputs, newInputs, prov, allowUnknowns, ignoreChanges)
+	return diffResource(urn, old.ID, oldInputs, oldOutputs, newInputs, prov, allowUnknowns, ignoreChanges, stats)
+}
+
+// inputsEqual returns true if the given inputs are deeply equal. If stats is non-nil, the work that the comparison
+// performs is recorded in it.
+func inputsEqual(oldInputs, newInputs resource.PropertyMap, stats *resource.DiffStats) bool {
+	if stats == nil {
+		return oldInputs.DeepEquals(newInputs)
+	}
+	return oldInputs.DeepEqualsWithStats(newInputs, stats)
 }
 
-// diffResource invokes the Diff function for the given custom resource's provider and returns the result.
+// diffResource invokes the Diff function for the given custom resource's provider and returns the result. If stats is
+// non-nil, the work performed by any comparison of the inputs is recorded in it.
 func diffResource(urn resource.URN, id resource.ID, oldInputs, oldOutputs,
 	newInputs resource.PropertyMap, prov plugin.Provider, allowUnknowns bool,
-	ignoreChanges []string) (plugin.DiffResult, error) {
+	ignoreChanges []string, stats *resource.DiffStats) (plugin.DiffResult, error) {
 
 	contract.Require(prov != nil, "prov != nil")
 
@@ -1067,7 +1086,7 @@ func diffResource(urn resource.URN, id resource.ID, oldInputs, oldOutputs,
 		return diff, err
 	}
 	if diff.Changes == plugin.DiffUnknown {
-		if oldInputs.DeepEquals(newInputs) {
+		if inputsEqual(oldInputs, newInputs, stats) {
 			diff.Changes = plugin.DiffNone
 		} else {
 			diff.Changes = plugin.DiffSome
@@ -113,3 +113,50 @@ func TestIgnoreChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateStepDefaults(t *testing.T) {
+	goal := &resource.Goal{
+		Type: "pkgA:m:typA",
+		Name: "resA",
+		Properties: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"alarmName": "alarm",
+		}),
+	}
+	new := &resource.State{
+		Type: goal.Type,
+		URN:  resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resA"),
+		Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"actionsEnabled": true,
+			"alarmName":      "alarm",
+			"__defaults":     []interface{}{"actionsEnabled"},
+		}),
+	}
+
+	step := NewCreateStep(nil, &testRegEvent{goal: goal}, new).(*CreateStep)
+	assert.Equal(t, []resource.PropertyKey{"actionsEnabled"}, step.Defaults())
+}
+
+func TestReplaceReasons(t *testing.T) {
+	provider := "urn:pulumi:stack::project::pulumi:providers:pkgA::default::id"
+	old := &resource.State{
+		Type:     "pkgA:m:typA",
+		URN:      resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resA"),
+		ID:       "id",
+		Custom:   true,
+		Provider: provider,
+	}
+	new := &resource.State{
+		Type:     old.Type,
+		URN:      old.URN,
+		Custom:   true,
+		Provider: provider,
+	}
+	keys := []resource.PropertyKey{"a", "b"}
+
+	replace := NewReplaceStep(nil, old, new, keys, keys, nil, true)
+	assert.Equal(t, keys, replace.ReplaceReasons())
+
+	same := NewSameStep(nil, &testRegEvent{goal: &resource.Goal{}}, old, new)
+	assert.NotNil(t, same.ReplaceReasons())
+	assert.Empty(t, same.ReplaceReasons())
+}
@@ -0,0 +1,86 @@
+// Copyright 2016-2020, Pulumi Corporation.  All rights reserved.
+
+package graph
+
+import (
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// Stats summarizes the shape of a dependency graph. A resource's dependencies are its explicit dependencies plus its
+// provider, if any; parent/child relationships are not considered edges.
+type Stats struct {
+	Resources int // the total number of resources in the graph.
+	MaxDepth  int // the number of resources in the longest dependency chain.
+	MaxWidth  int // the number of resources in the widest layer of mutually independent resources.
+	Leaves    int // the number of resources upon which no other resource depends.
+}
+
+// Stats computes statistics for the dependency graph. Resources are layered by depth: a resource with no dependencies
+// in the graph has depth 1, and every other resource sits one layer below its deepest dependency. Resources in the
+// same layer do not depend on one another, so the width of the widest layer is an upper bound on the parallelism
+// available to an update of the graph.
+//
+// Unlike the other DependencyGraph operations, Stats does not require the resources to be in topological order.
+func (dg *DependencyGraph) Stats() Stats {
+	byURN := make(map[resource.URN]*resource.State)
+	for _, res := range dg.resources {
+		byURN[res.URN] = res
+	}
+
+	dependencies := func(res *resource.State) []resource.URN {
+		deps := res.Dependencies
+		if res.Provider != "" {
+			ref, err := providers.ParseReference(res.Provider)
+			contract.Assert(err == nil)
+			deps = append(append([]resource.URN{}, deps...), ref.URN())
+		}
+		return deps
+	}
+
+	hasDependents := make(map[resource.URN]bool)
+	depths := make(map[resource.URN]int)
+	var depthOf func(res *resource.State) int
+	depthOf = func(res *resource.State) int {
+		if depth, ok := depths[res.URN]; ok {
+			contract.Assertf(depth != 0, "cycle in dependency graph at %v", res.URN)
+			return depth
+		}
+
+		// Mark the resource as in-progress so that cycles are detected rather than recursing forever.
+		depths[res.URN] = 0
+
+		depth := 1
+		for _, dep := range dependencies(res) {
+			if d, ok := byURN[dep]; ok {
+				hasDependents[dep] = true
+				if dd := depthOf(d) + 1; dd > depth {
+					depth = dd
+				}
+			}
+		}
+		depths[res.URN] = depth
+		return depth
+	}
+
+	stats := Stats{Resources: len(dg.resources)}
+	widths := make(map[int]int)
+	for _, res := range dg.resources {
+		depth := depthOf(res)
+		if depth > stats.MaxDepth {
+			stats.MaxDepth = depth
+		}
+		widths[depth]++
+		if widths[depth] > stats.MaxWidth {
+			stats.MaxWidth = widths[depth]
+		}
+	}
+	for _, res := range dg.resources {
+		if !hasDependents[res.URN] {
+			stats.Leaves++
+		}
+	}
+
+	return stats
+}
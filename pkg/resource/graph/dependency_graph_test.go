@@ -173,3 +173,31 @@ func TestDependenciesOf(t *testing.T) {
 	assert.False(t, dDepends[b])
 	assert.False(t, dDepends[c])
 }
+
+func TestStats(t *testing.T) {
+	// A chain (a <- b <- c) with a branch off of its root (a <- d).
+	a := NewResource("a", nil)
+	b := NewResource("b", nil, a.URN)
+	c := NewResource("c", nil, b.URN)
+	d := NewResource("d", nil, a.URN)
+
+	dg := NewDependencyGraph([]*resource.State{
+		a,
+		b,
+		c,
+		d,
+	})
+	assert.Equal(t, Stats{Resources: 4, MaxDepth: 3, MaxWidth: 2, Leaves: 2}, dg.Stats())
+
+	// Providers count as dependencies.
+	pA := NewProviderResource("test", "pA", "0")
+	e := NewResource("e", pA)
+	f := NewResource("f", pA)
+
+	dg = NewDependencyGraph([]*resource.State{
+		pA,
+		e,
+		f,
+	})
+	assert.Equal(t, Stats{Resources: 3, MaxDepth: 2, MaxWidth: 2, Leaves: 2}, dg.Stats())
+}
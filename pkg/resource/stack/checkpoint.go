@@ -25,17 +25,54 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/secrets"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype/migrate"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/mapper"
 )
 
+// UnmarshalVersionedCheckpointToLatestCheckpoint unmarshals a checkpoint of any version and migrates it to the latest
+// version. Fields that are not part of the checkpoint's schema are ignored.
 func UnmarshalVersionedCheckpointToLatestCheckpoint(bytes []byte) (*apitype.CheckpointV3, error) {
+	return unmarshalVersionedCheckpoint(bytes, false)
+}
+
+// UnmarshalVersionedCheckpointToLatestCheckpointStrict is like UnmarshalVersionedCheckpointToLatestCheckpoint, but
+// fails with a mapper.MappingError listing every field that is not part of the schema for the checkpoint's version.
+func UnmarshalVersionedCheckpointToLatestCheckpointStrict(bytes []byte) (*apitype.CheckpointV3, error) {
+	return unmarshalVersionedCheckpoint(bytes, true)
+}
+
+func unmarshalVersionedCheckpoint(bytes []byte, strict bool) (*apitype.CheckpointV3, error) {
+	m := encoding.JSON
+	if strict {
+		m = encoding.JSONStrict
+	}
+
+	// Gather the unrecognized fields of the envelope and the checkpoint within it, so that they are reported together.
+	var failures []error
+	unmarshal := func(data []byte, v interface{}) error {
+		err := m.Unmarshal(data, v)
+		if merr, ok := err.(mapper.MappingError); ok {
+			failures = append(failures, merr.Failures()...)
+			return nil
+		}
+		return err
+	}
+
 	var versionedCheckpoint apitype.VersionedCheckpoint
 	if err := json.Unmarshal(bytes, &versionedCheckpoint); err != nil {
 		return nil, err
 	}
+	// Checkpoints from before we started to version things have no envelope, so there is nothing to check.
+	if strict && versionedCheckpoint.Version != 0 {
+		if err := unmarshal(bytes, &apitype.VersionedCheckpoint{}); err != nil {
+			return nil, err
+		}
+	}
 
+	var v3checkpoint apitype.CheckpointV3
 	switch versionedCheckpoint.Version {
 	case 0:
 		// The happens when we are loading a checkpoint file from before we started to version things. Go's
@@ -43,40 +80,39 @@ func UnmarshalVersionedCheckpointToLatestCheckpoint(bytes []byte) (*apitype.Chec
 		// After we upgrade, we could consider rewriting this code to use DisallowUnknownFields() on the decoder
 		// to have the old checkpoint not even deserialize as an apitype.VersionedCheckpoint.
 		var v1checkpoint apitype.CheckpointV1
-		if err := json.Unmarshal(bytes, &v1checkpoint); err != nil {
+		if err := unmarshal(bytes, &v1checkpoint); err != nil {
 			return nil, err
 		}
 
 		v2checkpoint := migrate.UpToCheckpointV2(v1checkpoint)
-		v3checkpoint := migrate.UpToCheckpointV3(v2checkpoint)
-		return &v3checkpoint, nil
+		v3checkpoint = migrate.UpToCheckpointV3(v2checkpoint)
 	case 1:
 		var v1checkpoint apitype.CheckpointV1
-		if err := json.Unmarshal(versionedCheckpoint.Checkpoint, &v1checkpoint); err != nil {
+		if err := unmarshal(versionedCheckpoint.Checkpoint, &v1checkpoint); err != nil {
 			return nil, err
 		}
 
 		v2checkpoint := migrate.UpToCheckpointV2(v1checkpoint)
-		v3checkpoint := migrate.UpToCheckpointV3(v2checkpoint)
-		return &v3checkpoint, nil
+		v3checkpoint = migrate.UpToCheckpointV3(v2checkpoint)
 	case 2:
 		var v2checkpoint apitype.CheckpointV2
-		if err := json.Unmarshal(versionedCheckpoint.Checkpoint, &v2checkpoint); err != nil {
+		if err := unmarshal(versionedCheckpoint.Checkpoint, &v2checkpoint); err != nil {
 			return nil, err
 		}
 
-		v3checkpoint := migrate.UpToCheckpointV3(v2checkpoint)
-		return &v3checkpoint, nil
+		v3checkpoint = migrate.UpToCheckpointV3(v2checkpoint)
 	case 3:
-		var v3checkpoint apitype.CheckpointV3
-		if err := json.Unmarshal(versionedCheckpoint.Checkpoint, &v3checkpoint); err != nil {
+		if err := unmarshal(versionedCheckpoint.Checkpoint, &v3checkpoint); err != nil {
 			return nil, err
 		}
-
-		return &v3checkpoint, nil
 	default:
 		return nil, errors.Errorf("unsupported checkpoint version %d", versionedCheckpoint.Version)
 	}
+
+	if len(failures) != 0 {
+		return nil, mapper.NewMappingError(failures)
+	}
+	return &v3checkpoint, nil
 }
 
 // SerializeCheckpoint turns a snapshot into a data structure suitable for serialization.
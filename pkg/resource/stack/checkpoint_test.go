@@ -15,10 +15,16 @@
 package stack
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/mapper"
 )
 
 func TestLoadV0Checkpoint(t *testing.T) {
@@ -40,3 +46,51 @@ func TestLoadV1Checkpoint(t *testing.T) {
 	assert.NotNil(t, chk.Latest)
 	assert.Len(t, chk.Latest.Resources, 30)
 }
+
+func TestLoadCheckpointStrict(t *testing.T) {
+	// Checkpoints with only recognized fields load as usual.
+	res := &resource.State{Type: "test", URN: resource.NewURN("dev", "proj", "", "test", "a")}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{res}, nil)
+	versioned, err := SerializeCheckpoint("dev", snap, nil, false)
+	assert.NoError(t, err)
+	bytes, err := json.Marshal(versioned)
+	assert.NoError(t, err)
+
+	chk, err := UnmarshalVersionedCheckpointToLatestCheckpointStrict(bytes)
+	assert.NoError(t, err)
+	if assert.NotNil(t, chk) {
+		assert.Len(t, chk.Latest.Resources, 1)
+	}
+
+	// Every unrecognized field is reported, both in the envelope and in the checkpoint within it.
+	bytes = []byte(`{
+		"version": 3,
+		"extra": true,
+		"checkpoint": {
+			"stack": "dev",
+			"latest": {"manifest": {"time": "2020-01-01T00:00:00Z", "magic": "", "version": ""}, "resources": [
+				{"urn": "urn:pulumi:dev::proj::test::a", "custom": false, "type": "test", "protected": true}
+			]}
+		}
+	}`)
+	_, err = UnmarshalVersionedCheckpointToLatestCheckpointStrict(bytes)
+	if assert.Error(t, err) {
+		merr, ok := err.(mapper.MappingError)
+		if assert.True(t, ok, "expected a mapper.MappingError, got %T", err) {
+			var fields []string
+			for _, failure := range merr.Failures() {
+				if uerr, ok := errors.Cause(failure).(*mapper.UnrecognizedError); ok {
+					fields = append(fields, uerr.Field())
+				}
+			}
+			assert.ElementsMatch(t, []string{"extra", "protected"}, fields)
+		}
+	}
+
+	// The same checkpoint loads without the strict check.
+	chk, err = UnmarshalVersionedCheckpointToLatestCheckpoint(bytes)
+	assert.NoError(t, err)
+	if assert.NotNil(t, chk) {
+		assert.Len(t, chk.Latest.Resources, 1)
+	}
+}
@@ -104,3 +104,27 @@ func TestTrimColorizedString(t *testing.T) {
 	actual = TrimColorizedString(plain, len("hello"))
 	assert.Equal(t, "hello", actual)
 }
+
+func TestApplyTheme(t *testing.T) {
+	defer func() {
+		assert.NoError(t, ApplyTheme(Themes[DefaultTheme]))
+	}()
+
+	assert.NoError(t, ApplyTheme(Themes["mono"]))
+	assert.Equal(t, Reset, SpecCreate)
+	assert.Equal(t, Bold, SpecHeadline)
+
+	assert.NoError(t, ApplyTheme(Theme{"create": "blue underline"}))
+	assert.Equal(t, Blue+Underline, SpecCreate)
+	assert.Equal(t, Bold, SpecHeadline)
+
+	// Invalid themes are rejected without changing any colors.
+	assert.Error(t, ApplyTheme(Theme{"headline": "red", "creat": "green"}))
+	assert.Error(t, ApplyTheme(Theme{"headline": "red", "create": "purple"}))
+	assert.Equal(t, Blue+Underline, SpecCreate)
+	assert.Equal(t, Bold, SpecHeadline)
+
+	assert.NoError(t, ApplyTheme(Themes[DefaultTheme]))
+	assert.Equal(t, Green, SpecCreate)
+	assert.Equal(t, BrightMagenta+Bold, SpecHeadline)
+}
@@ -0,0 +1,188 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colors
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Theme maps the names of the logical conditions described by the Spec* colors (e.g. "create" for SpecCreate) to the
+// colors that should be used for them. Each color is a space-separated list of color names, e.g. "bright-red bold".
+// Conditions that are absent from a theme keep their current color.
+type Theme map[string]string
+
+// DefaultTheme is the name of the theme that is used when no other theme is selected.
+const DefaultTheme = "dark"
+
+// Themes contains the predefined themes, indexed by name.
+var Themes = map[string]Theme{
+	// dark is the default theme, which is tuned for terminals with dark backgrounds.
+	"dark": {
+		"important":          "yellow",
+		"unimportant":        "none",
+		"debug":              "none",
+		"info":               "magenta",
+		"error":              "red",
+		"warning":            "yellow",
+		"headline":           "bright-magenta bold",
+		"subheadline":        "bold",
+		"prompt":             "cyan bold",
+		"attention":          "bright-red",
+		"note":               "none",
+		"create":             "green",
+		"update":             "yellow",
+		"replace":            "bright-magenta",
+		"delete":             "red",
+		"create-replacement": "bright-green",
+		"delete-replaced":    "bright-red",
+		"read":               "bright-cyan",
+	},
+	// light avoids the yellows and bright colors that are hard to read on terminals with light backgrounds.
+	"light": {
+		"important":          "magenta",
+		"unimportant":        "none",
+		"debug":              "none",
+		"info":               "blue",
+		"error":              "red",
+		"warning":            "magenta",
+		"headline":           "magenta bold",
+		"subheadline":        "bold",
+		"prompt":             "blue bold",
+		"attention":          "red bold",
+		"note":               "none",
+		"create":             "green",
+		"update":             "blue",
+		"replace":            "magenta",
+		"delete":             "red",
+		"create-replacement": "green bold",
+		"delete-replaced":    "red bold",
+		"read":               "cyan",
+	},
+	// mono uses no colors at all, only emphasis.
+	"mono": {
+		"important":          "bold",
+		"unimportant":        "none",
+		"debug":              "none",
+		"info":               "none",
+		"error":              "bold",
+		"warning":            "bold",
+		"headline":           "bold",
+		"subheadline":        "bold",
+		"prompt":             "bold",
+		"attention":          "bold",
+		"note":               "none",
+		"create":             "none",
+		"update":             "none",
+		"replace":            "none",
+		"delete":             "none",
+		"create-replacement": "none",
+		"delete-replaced":    "none",
+		"read":               "none",
+	},
+}
+
+// themeSpecs maps the names used in themes to the Spec* colors they control.
+var themeSpecs = map[string]*string{
+	"important":          &SpecImportant,
+	"unimportant":        &SpecUnimportant,
+	"debug":              &SpecDebug,
+	"info":               &SpecInfo,
+	"error":              &SpecError,
+	"warning":            &SpecWarning,
+	"headline":           &SpecHeadline,
+	"subheadline":        &SpecSubHeadline,
+	"prompt":             &SpecPrompt,
+	"attention":          &SpecAttention,
+	"note":               &SpecNote,
+	"create":             &SpecCreate,
+	"update":             &SpecUpdate,
+	"replace":            &SpecReplace,
+	"delete":             &SpecDelete,
+	"create-replacement": &SpecCreateReplacement,
+	"delete-replaced":    &SpecDeleteReplaced,
+	"read":               &SpecRead,
+}
+
+// themeColors maps the color names used in themes to their colorization commands.
+var themeColors = map[string]string{
+	"none":           Reset,
+	"bold":           Bold,
+	"underline":      Underline,
+	"red":            Red,
+	"green":          Green,
+	"yellow":         Yellow,
+	"blue":           Blue,
+	"magenta":        Magenta,
+	"cyan":           Cyan,
+	"bright-red":     BrightRed,
+	"bright-green":   BrightGreen,
+	"bright-blue":    BrightBlue,
+	"bright-magenta": BrightMagenta,
+	"bright-cyan":    BrightCyan,
+}
+
+// ApplyTheme sets the Spec* colors named by the given theme. If the theme refers to an unknown condition or color,
+// an error is returned and no colors are changed.
+func ApplyTheme(theme Theme) error {
+	// Validate the whole theme before changing anything so that a bad theme is not partially applied.
+	specs := make(map[*string]string, len(theme))
+	for name, value := range theme {
+		spec, ok := themeSpecs[name]
+		if !ok {
+			return errors.Errorf("unknown color theme condition '%s'; supported conditions are: %s",
+				name, strings.Join(themeSpecNames(), ", "))
+		}
+
+		var color string
+		for _, colorName := range strings.Fields(value) {
+			c, ok := themeColors[colorName]
+			if !ok {
+				return errors.Errorf("unknown color '%s' for condition '%s'; supported colors are: %s",
+					colorName, name, strings.Join(themeColorNames(), ", "))
+			}
+			color += c
+		}
+		if color == "" {
+			color = Reset
+		}
+		specs[spec] = color
+	}
+
+	for spec, color := range specs {
+		*spec = color
+	}
+	return nil
+}
+
+func themeSpecNames() []string {
+	names := make([]string, 0, len(themeSpecs))
+	for name := range themeSpecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func themeColorNames() []string {
+	names := make([]string, 0, len(themeColors))
+	for name := range themeColors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
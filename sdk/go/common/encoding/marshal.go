@@ -15,15 +15,28 @@
 package encoding
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"io/ioutil"
 	"path/filepath"
+	"reflect"
+	"strings"
 
+	"github.com/pkg/errors"
 	yaml "gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/mapper"
 )
 
 var JSONExt = ".json"
 var YAMLExt = ".yaml"
 
+// GZIPExt is the extension of gzip-compressed files. It follows the extension of the compressed markup, e.g.
+// `deployment.json.gz`.
+var GZIPExt = ".gz"
+
 // Exts contains a list of all the valid marshalable extension types.
 var Exts = []string{
 	JSONExt,
@@ -32,8 +45,17 @@ var Exts = []string{
 	".yml",
 }
 
-// Detect auto-detects a marshaler for the given path.
+// Detect auto-detects a marshaler for the given path. Paths of compressed files (see IsCompressed) are detected by the
+// extension that precedes GZIPExt, and get a marshaler that compresses and decompresses that markup.
 func Detect(path string) (Marshaler, string) {
+	if IsCompressed(path) {
+		m, ext := Detect(strings.TrimSuffix(path, GZIPExt))
+		if m == nil {
+			return nil, ext + GZIPExt
+		}
+		return Gzip(m), ext + GZIPExt
+	}
+
 	ext := filepath.Ext(path)
 	if ext == "" {
 		ext = DefaultExt() // default to the first (preferred) marshaler.
@@ -41,6 +63,11 @@ func Detect(path string) (Marshaler, string) {
 	return Marshalers[ext], ext
 }
 
+// IsCompressed returns true if the given path names a gzip-compressed file.
+func IsCompressed(path string) bool {
+	return filepath.Ext(path) == GZIPExt
+}
+
 // Marshalers is a map of extension to a Marshaler object for that extension.
 var Marshalers map[string]Marshaler
 
@@ -64,7 +91,12 @@ type Marshaler interface {
 
 var JSON Marshaler = &jsonMarshaler{}
 
+// JSONStrict is a JSON marshaler whose Unmarshal fails with a mapper.MappingError if the data contains fields that the
+// target struct does not have.
+var JSONStrict Marshaler = &jsonMarshaler{strict: true}
+
 type jsonMarshaler struct {
+	strict bool
 }
 
 func (m *jsonMarshaler) IsJSONLike() bool {
@@ -80,9 +112,52 @@ func (m *jsonMarshaler) Marshal(v interface{}) ([]byte, error) {
 }
 
 func (m *jsonMarshaler) Unmarshal(data []byte, v interface{}) error {
-	// IDEA: use a "strict" marshaler, so that we can warn on unrecognized keys (avoiding silly mistakes).  We should
-	//     set aside an officially sanctioned area in the metadata for extensibility by 3rd parties.
-	return json.Unmarshal(data, v)
+	if err := json.Unmarshal(data, v); err != nil || !m.strict {
+		return err
+	}
+
+	// Decode the data a second time without a target type so that the mapper can check it for unrecognized fields.
+	// The mapper reports every unrecognized field at once, rather than only the first as
+	// json.Decoder.DisallowUnknownFields would.
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+		return nil
+	}
+	return unrecognizedFields(obj, v)
+}
+
+// unrecognizedFields returns a mapper.MappingError containing a mapper.UnrecognizedError for each field in the given
+// JSON-like object that has no counterpart in the target, which must be a pointer to a struct. Fields within nested
+// structs are checked as well. Other decoding failures are ignored, as the target has already been decoded.
+func unrecognizedFields(obj map[string]interface{}, v interface{}) error {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	md := mapper.New(&mapper.Opts{IgnoreMissing: true})
+	merr := md.Decode(obj, reflect.New(t.Elem()).Interface())
+	if merr == nil {
+		return nil
+	}
+
+	var failures []error
+	var collect func(merr mapper.MappingError)
+	collect = func(merr mapper.MappingError) {
+		for _, failure := range merr.Failures() {
+			switch f := errors.Cause(failure).(type) {
+			case *mapper.UnrecognizedError:
+				failures = append(failures, f)
+			case mapper.MappingError:
+				collect(f)
+			}
+		}
+	}
+	collect(merr)
+	if len(failures) == 0 {
+		return nil
+	}
+	return mapper.NewMappingError(failures)
 }
 
 var YAML Marshaler = &yamlMarshaler{}
@@ -108,3 +183,51 @@ func (m *yamlMarshaler) Unmarshal(data []byte, v interface{}) error {
 
 	return yaml.Unmarshal(data, v)
 }
+
+// Gzip returns a marshaler that compresses the output of the given marshaler with gzip, and decompresses its input.
+func Gzip(m Marshaler) Marshaler {
+	return &gzipMarshaler{inner: m}
+}
+
+type gzipMarshaler struct {
+	inner Marshaler
+}
+
+func (m *gzipMarshaler) IsJSONLike() bool {
+	return m.inner.IsJSONLike()
+}
+
+func (m *gzipMarshaler) IsYAMLLike() bool {
+	return m.inner.IsYAMLLike()
+}
+
+func (m *gzipMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.inner.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *gzipMarshaler) Unmarshal(data []byte, v interface{}) error {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(r)
+
+	data, err = ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return m.inner.Unmarshal(data, v)
+}
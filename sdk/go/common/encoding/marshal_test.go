@@ -0,0 +1,96 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/mapper"
+)
+
+type strictTestType struct {
+	Name   string            `json:"name" yaml:"name"`
+	Nested *strictTestType   `json:"nested,omitempty" yaml:"nested,omitempty"`
+	Items  []strictTestType  `json:"items,omitempty" yaml:"items,omitempty"`
+	Extra  map[string]string `json:"extra,omitempty" yaml:"extra,omitempty"`
+}
+
+// unrecognizedFieldNames returns the names of the fields in the given error's mapper.UnrecognizedErrors.
+func unrecognizedFieldNames(t *testing.T, err error) []string {
+	merr, ok := err.(mapper.MappingError)
+	if !assert.True(t, ok, "expected a mapper.MappingError; got %v", err) {
+		return nil
+	}
+	var names []string
+	for _, failure := range merr.Failures() {
+		if assert.IsType(t, &mapper.UnrecognizedError{}, failure) {
+			names = append(names, failure.(*mapper.UnrecognizedError).Field())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestStrictUnmarshal(t *testing.T) {
+	var v strictTestType
+
+	assert.NoError(t, JSON.Unmarshal([]byte(`{"name": "a", "nmae": "b"}`), &v))
+	assert.Equal(t, "a", v.Name)
+	assert.NoError(t, JSONStrict.Unmarshal([]byte(`{"name": "c", "extra": {"anything": "goes"}}`), &v))
+	assert.Equal(t, "c", v.Name)
+
+	// Every unrecognized field is reported, including those in nested structs.
+	err := JSONStrict.Unmarshal([]byte(`{"name": "a", "nmae": "b", "naem": "c"}`), &v)
+	assert.Equal(t, []string{"naem", "nmae"}, unrecognizedFieldNames(t, err))
+	err = JSONStrict.Unmarshal([]byte(`{"name": "a", "nested": {"name": "b", "nmae": "c"}}`), &v)
+	assert.Equal(t, []string{"nmae"}, unrecognizedFieldNames(t, err))
+	err = JSONStrict.Unmarshal([]byte(`{"name": "a", "items": [{"name": "b", "naem": "c"}]}`), &v)
+	assert.Equal(t, []string{"naem"}, unrecognizedFieldNames(t, err))
+
+	// Trailing data is rejected, as it is by the non-strict marshaler.
+	assert.Error(t, JSONStrict.Unmarshal([]byte(`{"name": "a"} {"name": "b"}`), &v))
+}
+
+func TestDetectCompressed(t *testing.T) {
+	m, ext := Detect("deployment.json.gz")
+	assert.Equal(t, ".json.gz", ext)
+	if assert.NotNil(t, m) {
+		assert.True(t, m.IsJSONLike())
+
+		data, err := m.Marshal(strictTestType{Name: "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x1f, 0x8b}, data[:2])
+
+		var v strictTestType
+		assert.NoError(t, m.Unmarshal(data, &v))
+		assert.Equal(t, "a", v.Name)
+	}
+
+	m, ext = Detect("deployment.yaml.gz")
+	assert.Equal(t, ".yaml.gz", ext)
+	if assert.NotNil(t, m) {
+		assert.True(t, m.IsYAMLLike())
+	}
+
+	m, ext = Detect("deployment.txt.gz")
+	assert.Equal(t, ".txt.gz", ext)
+	assert.Nil(t, m)
+
+	assert.True(t, IsCompressed("deployment.json.gz"))
+	assert.False(t, IsCompressed("deployment.json"))
+}
@@ -31,6 +31,14 @@ type Context struct {
 	Host       Host      // the host that can be used to fetch providers.
 	Pwd        string    // the working directory to spawn all plugins in.
 
+	// MaxProviders is the maximum number of resource provider plugins that the default host keeps running at once
+	// (<=0 for no limit). Idle providers are shut down to stay within the limit and restarted when they are next used.
+	MaxProviders int
+
+	// DiffStats is true if the engine should record how much work comparing each resource's inputs takes while
+	// planning, and report it in the summary of the update.
+	DiffStats bool
+
 	tracingSpan opentracing.Span // the OpenTracing span to parent requests within.
 }
 
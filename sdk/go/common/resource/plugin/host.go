@@ -99,6 +99,7 @@ func NewDefaultHost(ctx *Context, config ConfigSource, runtimeOptions map[string
 		reportedResourcePlugins: make(map[string]struct{}),
 		loadRequests:            make(chan pluginLoadRequest),
 	}
+	host.providers = NewProviderPool(func() int { return host.ctx.MaxProviders })
 
 	// Fire up a gRPC server to listen for requests.  This acts as a RPC interface that plugins can use
 	// to "phone home" in case there are things the host must do on behalf of the plugins (like log, etc).
@@ -141,6 +142,7 @@ type defaultHost struct {
 	reportedResourcePlugins map[string]struct{}              // the set of unique resource plugins we'll report.
 	plugins                 []workspace.PluginInfo           // a list of plugins allocated by this host.
 	loadRequests            chan pluginLoadRequest           // a channel used to satisfy plugin load requests.
+	providers               *ProviderPool                    // the pool that bounds running resource plugins.
 	server                  *hostServer                      // the server's RPC machinery.
 }
 
@@ -256,50 +258,59 @@ func (host *defaultHost) ListAnalyzers() []Analyzer {
 }
 
 func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (Provider, error) {
-	plugin, err := host.loadPlugin(func() (interface{}, error) {
-		// Try to load and bind to a plugin.
-		plug, err := NewProvider(host, host.ctx, pkg, version, host.runtimeOptions)
-		if err == nil && plug != nil {
-			info, infoerr := plug.GetPluginInfo()
-			if infoerr != nil {
-				return nil, infoerr
-			}
+	// Resource plugins are started through the provider pool, which may shut them down while they are idle and start
+	// them again on demand. Each start is still serialized through the plugin loader.
+	plug := host.providers.NewProvider(pkg, func() (Provider, error) {
+		plugin, err := host.loadPlugin(func() (interface{}, error) {
+			return NewProvider(host, host.ctx, pkg, version, host.runtimeOptions)
+		})
+		if plugin == nil || err != nil {
+			return nil, err
+		}
+		return plugin.(Provider), nil
+	})
 
-			// Warn if the plugin version was not what we expected
-			if version != nil && !cmdutil.IsTruthy(os.Getenv("PULUMI_DEV")) {
-				if info.Version == nil || !info.Version.GTE(*version) {
-					var v string
-					if info.Version != nil {
-						v = info.Version.String()
-					}
-					host.ctx.Diag.Warningf(
-						diag.Message("", /*urn*/
-							"resource plugin %s is expected to have version >=%s, but has %s; "+
-								"the wrong version may be on your path, or this may be a bug in the plugin"),
-						info.Name, version.String(), v)
-				}
-			}
+	// Try to load and bind to a plugin.
+	info, err := plug.GetPluginInfo()
+	if err != nil {
+		contract.IgnoreClose(plug)
+		return nil, err
+	}
 
-			// Record the result and add the plugin's info to our list of loaded plugins if it's the first copy of its
-			// kind.
-			key := info.Name
-			if info.Version != nil {
-				key += info.Version.String()
-			}
-			_, alreadyReported := host.reportedResourcePlugins[key]
-			if !alreadyReported {
-				host.reportedResourcePlugins[key] = struct{}{}
-				host.plugins = append(host.plugins, info)
+	_, err = host.loadPlugin(func() (interface{}, error) {
+		// Warn if the plugin version was not what we expected
+		if version != nil && !cmdutil.IsTruthy(os.Getenv("PULUMI_DEV")) {
+			if info.Version == nil || !info.Version.GTE(*version) {
+				var v string
+				if info.Version != nil {
+					v = info.Version.String()
+				}
+				host.ctx.Diag.Warningf(
+					diag.Message("", /*urn*/
+						"resource plugin %s is expected to have version >=%s, but has %s; "+
+							"the wrong version may be on your path, or this may be a bug in the plugin"),
+					info.Name, version.String(), v)
 			}
-			host.resourcePlugins[plug] = &resourcePlugin{Plugin: plug, Info: info}
 		}
 
-		return plug, err
+		// Record the result and add the plugin's info to our list of loaded plugins if it's the first copy of its
+		// kind.
+		key := info.Name
+		if info.Version != nil {
+			key += info.Version.String()
+		}
+		_, alreadyReported := host.reportedResourcePlugins[key]
+		if !alreadyReported {
+			host.reportedResourcePlugins[key] = struct{}{}
+			host.plugins = append(host.plugins, info)
+		}
+		host.resourcePlugins[plug] = &resourcePlugin{Plugin: plug, Info: info}
+		return nil, nil
 	})
-	if plugin == nil || err != nil {
+	if err != nil {
 		return nil, err
 	}
-	return plugin.(Provider), nil
+	return plug, nil
 }
 
 func (host *defaultHost) LanguageRuntime(runtime string) (LanguageRuntime, error) {
@@ -0,0 +1,397 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// ProviderPool bounds the number of provider plugins that are running at once. Every provider handed out by the pool
+// starts its plugin on demand. When the pool is full, the plugin that has been idle the longest is shut down to make
+// room; a provider whose plugin was shut down starts and reconfigures a fresh copy the next time it is used. A plugin
+// is never shut down while a call to it is in flight, so calls wait for a free slot instead.
+type ProviderPool struct {
+	limit func() int // returns the maximum number of running plugins (<=0 for no limit).
+
+	m       sync.Mutex
+	cond    *sync.Cond
+	running int               // the number of plugins that are running or starting.
+	idle    []*pooledProvider // the running providers that have no calls in flight, least recently used first.
+}
+
+// NewProviderPool returns a pool that runs at most limit() provider plugins at once (<=0 for no limit). The default
+// host bounds its plugins to Context.MaxProviders this way; other Host implementations may do the same.
+func NewProviderPool(limit func() int) *ProviderPool {
+	pool := &ProviderPool{limit: limit}
+	pool.cond = sync.NewCond(&pool.m)
+	return pool
+}
+
+// pooledProvider is a Provider whose plugin is managed by a ProviderPool.
+type pooledProvider struct {
+	pool *ProviderPool
+	pkg  tokens.Package
+	load func() (Provider, error) // starts a new copy of the provider's plugin.
+
+	// The fields below are protected by the pool's lock.
+	plugin   Provider             // the running plugin, if any.
+	starting bool                 // true while a plugin is being started.
+	calls    int                  // the number of calls to the plugin that are in flight.
+	closed   bool                 // true once the provider has been closed.
+	config   resource.PropertyMap // the inputs the provider was last configured with, if any.
+	info     *workspace.PluginInfo
+}
+
+var _ Provider = (*pooledProvider)(nil)
+
+// NewProvider returns a provider for the given package whose plugin is started by load.
+func (pool *ProviderPool) NewProvider(pkg tokens.Package, load func() (Provider, error)) Provider {
+	return &pooledProvider{pool: pool, pkg: pkg, load: load}
+}
+
+// removeIdle removes the given provider from the idle list, if it is present.
+func (pool *ProviderPool) removeIdle(p *pooledProvider) {
+	for i, q := range pool.idle {
+		if q == p {
+			pool.idle = append(pool.idle[:i], pool.idle[i+1:]...)
+			return
+		}
+	}
+}
+
+// acquire returns the provider's running plugin, starting it if necessary. Each successful call to acquire must be
+// paired with a call to release.
+func (p *pooledProvider) acquire() (Provider, error) {
+	pool := p.pool
+
+	pool.m.Lock()
+	var evicted Provider
+	for {
+		if p.closed {
+			pool.m.Unlock()
+			return nil, errors.Errorf("resource plugin %s has been closed", p.pkg)
+		}
+		if p.plugin != nil {
+			if p.calls == 0 {
+				pool.removeIdle(p)
+			}
+			p.calls++
+			plugin := p.plugin
+			pool.m.Unlock()
+			return plugin, nil
+		}
+		if p.starting {
+			pool.cond.Wait()
+			continue
+		}
+
+		if limit := pool.limit(); limit <= 0 || pool.running < limit {
+			pool.running++
+			break
+		}
+
+		// The pool is full. If a plugin is idle, shut down the least recently used one and take its slot; otherwise,
+		// wait for a call to finish.
+		if len(pool.idle) > 0 {
+			victim := pool.idle[0]
+			pool.idle = pool.idle[1:]
+			evicted, victim.plugin = victim.plugin, nil
+			break
+		}
+		pool.cond.Wait()
+	}
+	p.starting, p.calls = true, p.calls+1
+	config := p.config
+	pool.m.Unlock()
+
+	if evicted != nil {
+		logging.V(7).Infof("shutting down idle resource plugin %s to start %s", evicted.Pkg(), p.pkg)
+		if err := evicted.Close(); err != nil {
+			logging.V(5).Infof("Error closing '%s' resource plugin; ignoring: %v", evicted.Pkg(), err)
+		}
+	}
+
+	plugin, err := p.start(config)
+
+	pool.m.Lock()
+	defer pool.m.Unlock()
+	p.starting = false
+	if err != nil {
+		p.calls--
+		pool.running--
+		pool.cond.Broadcast()
+		return nil, err
+	}
+	p.plugin = plugin
+	pool.cond.Broadcast()
+	return plugin, nil
+}
+
+// start starts a new copy of the provider's plugin and, if the provider has been configured, reconfigures it.
+func (p *pooledProvider) start(config resource.PropertyMap) (Provider, error) {
+	plugin, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	contract.Assertf(plugin != nil, "unexpected nil resource plugin for %s", p.pkg)
+
+	if config != nil {
+		logging.V(7).Infof("restarting resource plugin %s", p.pkg)
+		if err = plugin.Configure(config); err != nil {
+			contract.IgnoreClose(plugin)
+			return nil, err
+		}
+	}
+	return plugin, nil
+}
+
+// release marks a call to the provider's plugin as finished. If the provider has been closed and this was its last
+// call, its plugin is shut down.
+func (p *pooledProvider) release() {
+	pool := p.pool
+
+	pool.m.Lock()
+	contract.Assert(p.calls > 0)
+	p.calls--
+
+	var closing Provider
+	if p.calls == 0 {
+		if p.closed {
+			closing, p.plugin = p.plugin, nil
+			pool.running--
+		} else {
+			pool.idle = append(pool.idle, p)
+		}
+	}
+	pool.cond.Broadcast()
+	pool.m.Unlock()
+
+	if closing != nil {
+		if err := closing.Close(); err != nil {
+			logging.V(5).Infof("Error closing '%s' resource plugin; ignoring: %v", p.pkg, err)
+		}
+	}
+}
+
+// Close shuts down the provider's plugin. If calls to the plugin are in flight, it is shut down once they finish.
+func (p *pooledProvider) Close() error {
+	pool := p.pool
+
+	pool.m.Lock()
+	if p.closed {
+		pool.m.Unlock()
+		return nil
+	}
+	p.closed = true
+
+	var closing Provider
+	if p.calls == 0 && p.plugin != nil {
+		pool.removeIdle(p)
+		closing, p.plugin = p.plugin, nil
+		pool.running--
+	}
+	pool.cond.Broadcast()
+	pool.m.Unlock()
+
+	if closing != nil {
+		return closing.Close()
+	}
+	return nil
+}
+
+func (p *pooledProvider) Pkg() tokens.Package { return p.pkg }
+
+func (p *pooledProvider) GetSchema(version int) ([]byte, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer p.release()
+	return plugin.GetSchema(version)
+}
+
+func (p *pooledProvider) CheckConfig(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool) (resource.PropertyMap, []CheckFailure, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer p.release()
+	return plugin.CheckConfig(urn, olds, news, allowUnknowns)
+}
+
+func (p *pooledProvider) DiffConfig(urn resource.URN, olds, news resource.PropertyMap, allowUnknowns bool,
+	ignoreChanges []string) (DiffResult, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return DiffResult{}, err
+	}
+	defer p.release()
+	return plugin.DiffConfig(urn, olds, news, allowUnknowns, ignoreChanges)
+}
+
+// Configure configures the provider's plugin and records the inputs so that a restarted plugin can be configured
+// identically.
+func (p *pooledProvider) Configure(inputs resource.PropertyMap) error {
+	plugin, err := p.acquire()
+	if err != nil {
+		return err
+	}
+	defer p.release()
+
+	if err = plugin.Configure(inputs); err != nil {
+		return err
+	}
+
+	p.pool.m.Lock()
+	defer p.pool.m.Unlock()
+	p.config = inputs.Copy()
+	return nil
+}
+
+func (p *pooledProvider) Check(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool) (resource.PropertyMap, []CheckFailure, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer p.release()
+	return plugin.Check(urn, olds, news, allowUnknowns)
+}
+
+func (p *pooledProvider) Diff(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+	news resource.PropertyMap, allowUnknowns bool, ignoreChanges []string) (DiffResult, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return DiffResult{}, err
+	}
+	defer p.release()
+	return plugin.Diff(urn, id, olds, news, allowUnknowns, ignoreChanges)
+}
+
+func (p *pooledProvider) Create(urn resource.URN, news resource.PropertyMap, timeout float64) (resource.ID,
+	resource.PropertyMap, resource.Status, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return "", nil, resource.StatusOK, err
+	}
+	defer p.release()
+	return plugin.Create(urn, news, timeout)
+}
+
+func (p *pooledProvider) Read(urn resource.URN, id resource.ID,
+	inputs, state resource.PropertyMap) (ReadResult, resource.Status, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return ReadResult{}, resource.StatusOK, err
+	}
+	defer p.release()
+	return plugin.Read(urn, id, inputs, state)
+}
+
+func (p *pooledProvider) Update(urn resource.URN, id resource.ID,
+	olds resource.PropertyMap, news resource.PropertyMap, timeout float64,
+	ignoreChanges []string) (resource.PropertyMap, resource.Status, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return nil, resource.StatusOK, err
+	}
+	defer p.release()
+	return plugin.Update(urn, id, olds, news, timeout, ignoreChanges)
+}
+
+func (p *pooledProvider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return resource.StatusOK, err
+	}
+	defer p.release()
+	return plugin.Delete(urn, id, props, timeout)
+}
+
+func (p *pooledProvider) Invoke(tok tokens.ModuleMember,
+	args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer p.release()
+	return plugin.Invoke(tok, args)
+}
+
+func (p *pooledProvider) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]CheckFailure, error) {
+	plugin, err := p.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer p.release()
+	return plugin.StreamInvoke(tok, args, onNext)
+}
+
+// GetPluginInfo returns the plugin's information. The information is cached so that it can be returned without
+// restarting a plugin that has been shut down.
+func (p *pooledProvider) GetPluginInfo() (workspace.PluginInfo, error) {
+	p.pool.m.Lock()
+	info := p.info
+	p.pool.m.Unlock()
+	if info != nil {
+		return *info, nil
+	}
+
+	plugin, err := p.acquire()
+	if err != nil {
+		return workspace.PluginInfo{}, err
+	}
+	defer p.release()
+
+	result, err := plugin.GetPluginInfo()
+	if err != nil {
+		return workspace.PluginInfo{}, err
+	}
+
+	p.pool.m.Lock()
+	defer p.pool.m.Unlock()
+	p.info = &result
+	return result, nil
+}
+
+// SignalCancellation forwards the cancellation to the provider's plugin if it is running. A plugin that is not
+// running has no operations to cancel.
+func (p *pooledProvider) SignalCancellation() error {
+	p.pool.m.Lock()
+	plugin := p.plugin
+	if plugin == nil {
+		p.pool.m.Unlock()
+		return nil
+	}
+	if p.calls == 0 {
+		p.pool.removeIdle(p)
+	}
+	p.calls++
+	p.pool.m.Unlock()
+
+	defer p.release()
+	return plugin.SignalCancellation()
+}
@@ -0,0 +1,151 @@
+package plugin
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// testPlugins tracks the fake plugin processes started for a test.
+type testPlugins struct {
+	m       sync.Mutex
+	running int
+	maximum int
+	started map[tokens.Package]int
+}
+
+func (plugins *testPlugins) load(pkg tokens.Package) func() (Provider, error) {
+	return func() (Provider, error) {
+		plugins.m.Lock()
+		defer plugins.m.Unlock()
+		plugins.running++
+		if plugins.running > plugins.maximum {
+			plugins.maximum = plugins.running
+		}
+		plugins.started[pkg]++
+		return &testPlugin{plugins: plugins, pkg: pkg}, nil
+	}
+}
+
+// testPlugin is a fake provider plugin that implements the subset of Provider used by these tests.
+type testPlugin struct {
+	Provider
+
+	plugins *testPlugins
+	pkg     tokens.Package
+	config  resource.PropertyMap
+	closed  bool
+}
+
+func (p *testPlugin) Pkg() tokens.Package { return p.pkg }
+
+func (p *testPlugin) Configure(inputs resource.PropertyMap) error {
+	p.config = inputs
+	return nil
+}
+
+func (p *testPlugin) Create(urn resource.URN, news resource.PropertyMap, timeout float64) (resource.ID,
+	resource.PropertyMap, resource.Status, error) {
+	if p.closed {
+		return "", nil, resource.StatusUnknown, fmt.Errorf("%s was closed before Create", p.pkg)
+	}
+	time.Sleep(time.Millisecond)
+	if p.closed {
+		return "", nil, resource.StatusUnknown, fmt.Errorf("%s was closed during Create", p.pkg)
+	}
+	return resource.ID(p.config["region"].StringValue()), news, resource.StatusOK, nil
+}
+
+func (p *testPlugin) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{Name: string(p.pkg), Kind: workspace.ResourcePlugin}, nil
+}
+
+func (p *testPlugin) Close() error {
+	p.plugins.m.Lock()
+	defer p.plugins.m.Unlock()
+	p.closed = true
+	p.plugins.running--
+	return nil
+}
+
+func TestProviderPoolBoundsRunningPlugins(t *testing.T) {
+	const limit = 2
+
+	plugins := &testPlugins{started: map[tokens.Package]int{}}
+	pool := NewProviderPool(func() int { return limit })
+
+	// Load and configure several providers, as the provider registry does at the start of a deployment.
+	var providers []Provider
+	for i := 0; i < 5; i++ {
+		pkg := tokens.Package(fmt.Sprintf("pkg%d", i))
+		p := pool.NewProvider(pkg, plugins.load(pkg))
+		_, err := p.GetPluginInfo()
+		assert.NoError(t, err)
+		assert.NoError(t, p.Configure(resource.PropertyMap{
+			"region": resource.NewStringProperty(string(pkg)),
+		}))
+		providers = append(providers, p)
+	}
+
+	// Run many concurrent steps against all of the providers.
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		p := providers[i%len(providers)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, _, _, err := p.Create("", nil, 0)
+			assert.NoError(t, err)
+
+			// A restarted plugin must have been reconfigured with the provider's inputs.
+			assert.Equal(t, resource.ID(p.Pkg()), id)
+		}()
+	}
+	wg.Wait()
+
+	for _, p := range providers {
+		assert.NoError(t, p.Close())
+	}
+
+	assert.Equal(t, limit, plugins.maximum)
+	assert.Equal(t, 0, plugins.running)
+
+	// The first providers were shut down to make room for the later ones while they were being loaded, so they must
+	// have been restarted to run their steps.
+	for _, p := range providers[:len(providers)-limit] {
+		assert.True(t, plugins.started[p.Pkg()] > 1, "expected %s to be restarted", p.Pkg())
+	}
+}
+
+func TestProviderPoolUnbounded(t *testing.T) {
+	plugins := &testPlugins{started: map[tokens.Package]int{}}
+	pool := NewProviderPool(func() int { return 0 })
+
+	var providers []Provider
+	for i := 0; i < 5; i++ {
+		pkg := tokens.Package(fmt.Sprintf("pkg%d", i))
+		p := pool.NewProvider(pkg, plugins.load(pkg))
+		assert.NoError(t, p.Configure(resource.PropertyMap{
+			"region": resource.NewStringProperty(string(pkg)),
+		}))
+		providers = append(providers, p)
+	}
+	for _, p := range providers {
+		_, _, _, err := p.Create("", nil, 0)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 5, plugins.running)
+	for _, p := range providers {
+		assert.Equal(t, 1, plugins.started[p.Pkg()])
+		assert.NoError(t, p.Close())
+	}
+	assert.Equal(t, 0, plugins.running)
+}
@@ -16,6 +16,8 @@ package resource
 
 import (
 	"sort"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 )
 
 // ObjectDiff holds the results of diffing two object property maps.
@@ -119,8 +121,26 @@ func (diff *ArrayDiff) Len() int {
 // IgnoreKeyFunc is the callback type for Diff's ignore option.
 type IgnoreKeyFunc func(key PropertyKey) bool
 
+// DiffStats records the amount of work performed while computing a diff.
+type DiffStats struct {
+	Comparisons int // the number of pairs of property values that were compared.
+	Recursions  int // the number of pairs of nested objects or arrays that were diffed.
+}
+
 // Diff returns a diffset by comparing the property map to another; it returns nil if there are no diffs.
 func (props PropertyMap) Diff(other PropertyMap, ignoreKeys ...IgnoreKeyFunc) *ObjectDiff {
+	return props.diff(other, nil, ignoreKeys)
+}
+
+// DiffWithStats is identical to Diff, but additionally accumulates the work performed into the given stats.
+func (props PropertyMap) DiffWithStats(other PropertyMap, stats *DiffStats,
+	ignoreKeys ...IgnoreKeyFunc) *ObjectDiff {
+
+	contract.Require(stats != nil, "stats")
+	return props.diff(other, stats, ignoreKeys)
+}
+
+func (props PropertyMap) diff(other PropertyMap, stats *DiffStats, ignoreKeys []IgnoreKeyFunc) *ObjectDiff {
 	adds := make(PropertyMap)
 	deletes := make(PropertyMap)
 	sames := make(PropertyMap)
@@ -145,7 +165,7 @@ func (props PropertyMap) Diff(other PropertyMap, ignoreKeys ...IgnoreKeyFunc) *O
 			// If a new exists, use it; for output properties, however, ignore differences.
 			if new.IsOutput() {
 				sames[k] = old
-			} else if diff := old.Diff(new, ignoreKeys...); diff != nil {
+			} else if diff := old.diff(new, stats, ignoreKeys); diff != nil {
 				if !old.HasValue() {
 					adds[k] = new
 				} else if !new.HasValue() {
@@ -187,7 +207,19 @@ func (props PropertyMap) Diff(other PropertyMap, ignoreKeys ...IgnoreKeyFunc) *O
 
 // Diff returns a diff by comparing a single property value to another; it returns nil if there are no diffs.
 func (v PropertyValue) Diff(other PropertyValue, ignoreKeys ...IgnoreKeyFunc) *ValueDiff {
+	return v.diff(other, nil, ignoreKeys)
+}
+
+func (v PropertyValue) diff(other PropertyValue, stats *DiffStats, ignoreKeys []IgnoreKeyFunc) *ValueDiff {
+	if stats != nil {
+		stats.Comparisons++
+	}
+
 	if v.IsArray() && other.IsArray() {
+		if stats != nil {
+			stats.Recursions++
+		}
+
 		old := v.ArrayValue()
 		new := other.ArrayValue()
 		// If any elements exist in the new array but not the old, track them as adds.
@@ -204,7 +236,7 @@ func (v PropertyValue) Diff(other PropertyValue, ignoreKeys ...IgnoreKeyFunc) *V
 		sames := make(map[int]PropertyValue)
 		updates := make(map[int]ValueDiff)
 		for i := 0; i < len(old) && i < len(new); i++ {
-			if diff := old[i].Diff(new[i]); diff != nil {
+			if diff := old[i].diff(new[i], stats, nil); diff != nil {
 				updates[i] = *diff
 			} else {
 				sames[i] = old[i]
@@ -226,9 +258,13 @@ func (v PropertyValue) Diff(other PropertyValue, ignoreKeys ...IgnoreKeyFunc) *V
 		}
 	}
 	if v.IsObject() && other.IsObject() {
+		if stats != nil {
+			stats.Recursions++
+		}
+
 		old := v.ObjectValue()
 		new := other.ObjectValue()
-		if diff := old.Diff(new, ignoreKeys...); diff != nil {
+		if diff := old.diff(new, stats, ignoreKeys); diff != nil {
 			return &ValueDiff{
 				Old:    v,
 				New:    other,
@@ -247,11 +283,22 @@ func (v PropertyValue) Diff(other PropertyValue, ignoreKeys ...IgnoreKeyFunc) *V
 
 // DeepEquals returns true if this property map is deeply equal to the other property map; and false otherwise.
 func (props PropertyMap) DeepEquals(other PropertyMap) bool {
+	return props.deepEquals(other, nil)
+}
+
+// DeepEqualsWithStats is identical to DeepEquals, but additionally accumulates the work performed into the given stats.
+// As DeepEquals stops at the first difference, this may be less work than a Diff of the same maps performs.
+func (props PropertyMap) DeepEqualsWithStats(other PropertyMap, stats *DiffStats) bool {
+	contract.Require(stats != nil, "stats")
+	return props.deepEquals(other, stats)
+}
+
+func (props PropertyMap) deepEquals(other PropertyMap, stats *DiffStats) bool {
 	// If any in props either doesn't exist, or is of a different value, return false.
 	for _, k := range props.StableKeys() {
 		v := props[k]
 		if p, has := other[k]; has {
-			if !v.DeepEquals(p) {
+			if !v.deepEquals(p, stats) {
 				return false
 			}
 		} else if v.HasValue() {
@@ -271,6 +318,14 @@ func (props PropertyMap) DeepEquals(other PropertyMap) bool {
 
 // DeepEquals returns true if this property map is deeply equal to the other property map; and false otherwise.
 func (v PropertyValue) DeepEquals(other PropertyValue) bool {
+	return v.deepEquals(other, nil)
+}
+
+func (v PropertyValue) deepEquals(other PropertyValue, stats *DiffStats) bool {
+	if stats != nil {
+		stats.Comparisons++
+	}
+
 	// Arrays are equal if they are both of the same size and elements are deeply equal.
 	if v.IsArray() {
 		if !other.IsArray() {
@@ -281,8 +336,11 @@ func (v PropertyValue) DeepEquals(other PropertyValue) bool {
 		if len(va) != len(oa) {
 			return false
 		}
+		if stats != nil {
+			stats.Recursions++
+		}
 		for i, elem := range va {
-			if !elem.DeepEquals(oa[i]) {
+			if !elem.deepEquals(oa[i], stats) {
 				return false
 			}
 		}
@@ -307,9 +365,12 @@ func (v PropertyValue) DeepEquals(other PropertyValue) bool {
 		if !other.IsObject() {
 			return false
 		}
+		if stats != nil {
+			stats.Recursions++
+		}
 		vo := v.ObjectValue()
 		oa := other.ObjectValue()
-		return vo.DeepEquals(oa)
+		return vo.deepEquals(oa, stats)
 	}
 
 	// Secret are equal if the value they wrap are equal.
@@ -320,7 +381,7 @@ func (v PropertyValue) DeepEquals(other PropertyValue) bool {
 		vs := v.SecretValue()
 		os := other.SecretValue()
 
-		return vs.Element.DeepEquals(os.Element)
+		return vs.Element.deepEquals(os.Element, stats)
 	}
 
 	// For all other cases, primitives are equal if their values are equal.
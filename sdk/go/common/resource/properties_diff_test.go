@@ -351,3 +351,61 @@ func TestMismatchedPropertyValueDiff(t *testing.T) {
 	assert.True(t, s2.DeepEquals(s1))
 	assert.True(t, s1.DeepEquals(s2))
 }
+
+func TestDiffStats(t *testing.T) {
+	t.Parallel()
+	olds := NewPropertyMapFromMap(map[string]interface{}{
+		"a": "x",
+		"b": map[string]interface{}{
+			"c": 1,
+			"d": []interface{}{1, 2},
+		},
+	})
+	news := NewPropertyMapFromMap(map[string]interface{}{
+		"a": "y",
+		"b": map[string]interface{}{
+			"c": 1,
+			"d": []interface{}{1, 3},
+		},
+		"e": true,
+	})
+
+	var stats DiffStats
+	diff := olds.DiffWithStats(news, &stats)
+	assert.Equal(t, olds.Diff(news), diff)
+
+	// a, b, b.c, b.d, b.d[0], and b.d[1] are compared; e is an add, so it is never compared. b and b.d are recursed
+	// into.
+	assert.Equal(t, 6, stats.Comparisons)
+	assert.Equal(t, 2, stats.Recursions)
+
+	// Ignored keys are never compared, and stats accumulate across calls.
+	olds.DiffWithStats(news, &stats, func(key PropertyKey) bool { return key == "b" })
+	assert.Equal(t, 7, stats.Comparisons)
+	assert.Equal(t, 2, stats.Recursions)
+}
+
+func TestDeepEqualsStats(t *testing.T) {
+	t.Parallel()
+	olds := NewPropertyMapFromMap(map[string]interface{}{
+		"a": "x",
+		"b": map[string]interface{}{
+			"c": 1,
+			"d": []interface{}{1, 2},
+		},
+	})
+
+	// a, b, b.c, b.d, b.d[0], and b.d[1] are compared, and b and b.d are recursed into.
+	var stats DiffStats
+	assert.True(t, olds.DeepEqualsWithStats(olds.Copy(), &stats))
+	assert.Equal(t, 6, stats.Comparisons)
+	assert.Equal(t, 2, stats.Recursions)
+
+	// DeepEquals stops at the first difference: a is compared before b, so only a is compared.
+	news := olds.Copy()
+	news["a"] = NewStringProperty("y")
+	stats = DiffStats{}
+	assert.False(t, olds.DeepEqualsWithStats(news, &stats))
+	assert.Equal(t, 1, stats.Comparisons)
+	assert.Equal(t, 0, stats.Recursions)
+}
@@ -258,3 +258,46 @@ func (p PropertyPath) Delete(dest PropertyValue) bool {
 	return true
 
 }
+
+// String returns the canonical string form of the PropertyPath. Property names that are valid identifiers are written
+// as `.name` accesses, and all other names are written as quoted `["name"]` accesses, so the result can be parsed by
+// ParsePropertyPath.
+func (p PropertyPath) String() string {
+	var sb strings.Builder
+	for _, key := range p {
+		switch key := key.(type) {
+		case int:
+			sb.WriteString("[")
+			sb.WriteString(strconv.Itoa(key))
+			sb.WriteString("]")
+		case string:
+			if isPropertyName(key) {
+				if sb.Len() != 0 {
+					sb.WriteString(".")
+				}
+				sb.WriteString(key)
+			} else {
+				sb.WriteString(`["`)
+				sb.WriteString(strings.ReplaceAll(key, `"`, `\"`))
+				sb.WriteString(`"]`)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// isPropertyName returns true if the given string matches the propertyName production of the property path grammar.
+func isPropertyName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c == '$':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
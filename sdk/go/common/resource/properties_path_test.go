@@ -120,6 +120,10 @@ func TestPropertyPath(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, c.parsed, parsed)
 
+			reparsed, err := ParsePropertyPath(parsed.String())
+			assert.NoError(t, err)
+			assert.Equal(t, parsed, reparsed)
+
 			v, ok := parsed.Get(value)
 			assert.True(t, ok)
 			assert.False(t, v.IsNull())
@@ -170,3 +174,12 @@ func TestPropertyPath(t *testing.T) {
 		})
 	}
 }
+
+func TestPropertyPathString(t *testing.T) {
+	assert.Equal(t, "root", PropertyPath{"root"}.String())
+	assert.Equal(t, "root.nested.array[0].double[1]",
+		PropertyPath{"root", "nested", "array", 0, "double", 1}.String())
+	assert.Equal(t, `root["key with \"escaped\" quotes"]`, PropertyPath{"root", `key with "escaped" quotes`}.String())
+	assert.Equal(t, `["root key with a ."][1]`, PropertyPath{"root key with a .", 1}.String())
+	assert.Equal(t, `root["0abc"]`, PropertyPath{"root", "0abc"}.String())
+}
@@ -28,6 +28,9 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
 )
 
+// ErrorExitCode is the standard exit code used when a command fails.
+const ErrorExitCode = -1
+
 // DetailedError extracts a detailed error message, including stack trace, if there is one.
 func DetailedError(err error) string {
 	msg := errorMessage(err)
@@ -108,6 +111,15 @@ func RunFunc(run func(cmd *cobra.Command, args []string) error) func(*cobra.Comm
 // default Cobra unhandled error behavior, because it is formatted incorrectly and needlessly prints
 // usage.
 func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) func(*cobra.Command, []string) {
+	return RunResultFuncWithExitCode(func() int { return ErrorExitCode }, run)
+}
+
+// RunResultFuncWithExitCode is like [RunResultFunc], except that a failing result exits with the code returned by
+// exitCode rather than the standard error exit code.  exitCode is only called once run has returned, so it may depend
+// on the command's flags.
+func RunResultFuncWithExitCode(exitCode func() int,
+	run func(cmd *cobra.Command, args []string) result.Result) func(*cobra.Command, []string) {
+
 	return func(cmd *cobra.Command, args []string) {
 		if res := run(cmd, args); res != nil {
 			// Sadly, the fact that we hard-exit below means that it's up to us to replicate the Cobra post-run
@@ -120,7 +132,7 @@ func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) fu
 			// to quit at this point (with an error code so no one thinks we succeeded).  Bailing
 			// always indicates a failure, just one we don't need to print a message for.
 			if res.IsBail() {
-				os.Exit(-1)
+				os.Exit(exitCode())
 				return
 			}
 
@@ -135,7 +147,7 @@ func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) fu
 				logging.V(3).Infof(DetailedError(err))
 			}
 
-			ExitError(msg)
+			exitErrorCodef(exitCode(), escapeFormat(msg))
 		}
 	}
 }
@@ -147,9 +159,13 @@ func Exit(err error) {
 
 // ExitError issues an error and exits with a standard error exit code.
 func ExitError(msg string) {
-	// Escape percent sign before passing the message as a format string (e.g., msg could contain %PATH% on Windows).
-	format := strings.Replace(msg, "%", "%%", -1)
-	exitErrorCodef(-1, format)
+	exitErrorCodef(ErrorExitCode, escapeFormat(msg))
+}
+
+// escapeFormat escapes the percent signs in msg so that it can be passed as a format string (e.g., msg could contain
+// %PATH% on Windows).
+func escapeFormat(msg string) string {
+	return strings.Replace(msg, "%", "%%", -1)
 }
 
 // exitErrorCodef formats the message with arguments, issues an error and exists with the given error exit code.
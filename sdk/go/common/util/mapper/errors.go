@@ -69,6 +69,7 @@ type fieldError struct {
 	Type    string
 	Fld     string
 	Message string
+	Err     error
 }
 
 var _ error = (*fieldError)(nil)      // ensure this implements the error interface.
@@ -79,6 +80,7 @@ func NewFieldError(ty string, fld string, err error) FieldError {
 		Type:    ty,
 		Fld:     fld,
 		Message: fmt.Sprintf("An error occurred decoding '%v.%v': %v", ty, fld, err),
+		Err:     err,
 	}
 }
 
@@ -90,6 +92,9 @@ func (e *fieldError) Error() string  { return e.Message }
 func (e *fieldError) Field() string  { return e.Fld }
 func (e *fieldError) Reason() string { return e.Message }
 
+// Cause returns the error that occurred decoding the field, which is a MappingError if the field is itself a struct.
+func (e *fieldError) Cause() error { return e.Err }
+
 // MissingError is used when a required field is missing on an object of a given type.
 type MissingError struct {
 	Type    reflect.Type
@@ -18,10 +18,14 @@ package workspace
 type Settings struct {
 	// Stack is an optional default stack to use.
 	Stack string `json:"stack,omitempty" yaml:"env,omitempty"`
+	// ColorTheme optionally overrides the colors used for output, by condition name (see colors.Theme).
+	ColorTheme map[string]string `json:"colorTheme,omitempty" yaml:"colorTheme,omitempty"`
+	// CheckpointBackupCount optionally sets how many backups of each checkpoint the filestate backend retains.
+	CheckpointBackupCount int `json:"checkpointBackupCount,omitempty" yaml:"checkpointBackupCount,omitempty"`
 }
 
-// IsEmpty returns true when the settings object is logically empty (no selected stack and nothing in the deprecated
-// configuration bag).
+// IsEmpty returns true when the settings object is logically empty (no selected stack, no color theme, no checkpoint
+// backup count, and nothing in the deprecated configuration bag).
 func (s *Settings) IsEmpty() bool {
-	return s.Stack == ""
+	return s.Stack == "" && len(s.ColorTheme) == 0 && s.CheckpointBackupCount == 0
 }
@@ -477,6 +477,7 @@ func (ctx *Context) RegisterResource(
 			Provider:                inputs.provider,
 			PropertyDependencies:    inputs.rpcPropertyDeps,
 			DeleteBeforeReplace:     inputs.deleteBeforeReplace,
+			RetainOnDelete:          inputs.retainOnDelete,
 			ImportId:                inputs.importID,
 			CustomTimeouts:          inputs.customTimeouts,
 			IgnoreChanges:           inputs.ignoreChanges,
@@ -786,6 +787,7 @@ type resourceInputs struct {
 	rpcProps                *structpb.Struct
 	rpcPropertyDeps         map[string]*pulumirpc.RegisterResourceRequest_PropertyDependencies
 	deleteBeforeReplace     bool
+	retainOnDelete          bool
 	importID                string
 	customTimeouts          *pulumirpc.RegisterResourceRequest_CustomTimeouts
 	ignoreChanges           []string
@@ -871,6 +873,7 @@ func (ctx *Context) prepareResourceInputs(props Input, t string,
 		rpcProps:                rpcProps,
 		rpcPropertyDeps:         rpcPropertyDeps,
 		deleteBeforeReplace:     deleteBeforeReplace,
+		retainOnDelete:          opts.RetainOnDelete,
 		importID:                string(importID),
 		customTimeouts:          getTimeouts(opts.CustomTimeouts),
 		ignoreChanges:           ignoreChanges,
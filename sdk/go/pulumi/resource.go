@@ -164,6 +164,9 @@ type resourceOptions struct {
 	Providers map[string]ProviderResource
 	// DeleteBeforeReplace, when set to true, ensures that this resource is deleted prior to replacement.
 	DeleteBeforeReplace bool
+	// RetainOnDelete, when set to true, ensures that deleting this resource only removes it from the stack: the
+	// resource's provider is not asked to delete the underlying cloud resource.
+	RetainOnDelete bool
 	// Import, when provided with a resource ID, indicates that this resource's provider should import its state from
 	// the cloud resource with the given ID. The inputs to the resource's constructor must align with the resource's
 	// current state. Once a resource has been imported, the import property must be removed from the resource's
@@ -302,6 +305,14 @@ func DeleteBeforeReplace(o bool) ResourceOption {
 	})
 }
 
+// RetainOnDelete, when set to true, ensures that deleting this resource only removes it from the stack: the
+// resource's provider is not asked to delete the underlying cloud resource.
+func RetainOnDelete(o bool) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.RetainOnDelete = o
+	})
+}
+
 // Import, when provided with a resource ID, indicates that this resource's provider should import its state from
 // the cloud resource with the given ID. The inputs to the resource's constructor must align with the resource's
 // current state. Once a resource has been imported, the import property must be removed from the resource's
@@ -139,6 +139,12 @@ func TestResourceOptionMergingDeleteBeforeReplace(t *testing.T) {
 	assert.Equal(t, false, opts.DeleteBeforeReplace)
 }
 
+func TestResourceOptionMergingRetainOnDelete(t *testing.T) {
+	// last value wins
+	opts := merge(RetainOnDelete(true), RetainOnDelete(false))
+	assert.Equal(t, false, opts.RetainOnDelete)
+}
+
 func TestResourceOptionMergingImport(t *testing.T) {
 	id1 := ID("a")
 	id2 := ID("a")
@@ -473,6 +473,11 @@ export interface ResourceOptions {
      * When set to true, protect ensures this resource cannot be deleted.
      */
     protect?: boolean;
+    /**
+     * When set to true, retainOnDelete ensures that deleting this resource only removes it from the stack: the
+     * resource's provider is not asked to delete the underlying cloud resource.
+     */
+    retainOnDelete?: boolean;
     /**
      * Ignore changes to any of the specified properties.
      */
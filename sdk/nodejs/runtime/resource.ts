@@ -183,6 +183,7 @@ export function registerResource(res: Resource, t: string, name: string, custom:
         req.setCustom(custom);
         req.setObject(gstruct.Struct.fromJavaScript(resop.serializedProps));
         req.setProtect(opts.protect);
+        req.setRetainondelete(opts.retainOnDelete || false);
         req.setProvider(resop.providerRef);
         req.setDependenciesList(Array.from(resop.allDirectDependencyURNs));
         req.setDeletebeforereplace((<any>opts).deleteBeforeReplace || false);
@@ -295,6 +295,7 @@ type RegisterResourceRequest struct {
 	CustomTimeouts             *RegisterResourceRequest_CustomTimeouts                  `protobuf:"bytes,17,opt,name=customTimeouts,proto3" json:"customTimeouts,omitempty"`
 	DeleteBeforeReplaceDefined bool                                                     `protobuf:"varint,18,opt,name=deleteBeforeReplaceDefined,proto3" json:"deleteBeforeReplaceDefined,omitempty"`
 	SupportsPartialValues      bool                                                     `protobuf:"varint,19,opt,name=supportsPartialValues,proto3" json:"supportsPartialValues,omitempty"`
+	RetainOnDelete             bool                                                     `protobuf:"varint,20,opt,name=retainOnDelete,proto3" json:"retainOnDelete,omitempty"`
 	XXX_NoUnkeyedLiteral       struct{}                                                 `json:"-"`
 	XXX_unrecognized           []byte                                                   `json:"-"`
 	XXX_sizecache              int32                                                    `json:"-"`
@@ -458,6 +459,13 @@ func (m *RegisterResourceRequest) GetSupportsPartialValues() bool {
 	return false
 }
 
+func (m *RegisterResourceRequest) GetRetainOnDelete() bool {
+	if m != nil {
+		return m.RetainOnDelete
+	}
+	return false
+}
+
 // PropertyDependencies describes the resources that a particular property depends on.
 type RegisterResourceRequest_PropertyDependencies struct {
 	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
@@ -697,63 +705,63 @@ func init() {
 func init() { proto.RegisterFile("resource.proto", fileDescriptor_d1b72f771c35e3b8) }
 
 var fileDescriptor_d1b72f771c35e3b8 = []byte{
-	// 882 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x51, 0x6f, 0x23, 0x35,
-	0x10, 0xbe, 0x4d, 0x7a, 0x69, 0x32, 0xed, 0xa5, 0xc5, 0x2d, 0xa9, 0x6f, 0x41, 0xa5, 0x2c, 0x3c,
-	0x04, 0x1e, 0xd2, 0xbb, 0x82, 0x74, 0x07, 0x42, 0x20, 0x71, 0x77, 0xa0, 0x7b, 0x38, 0x71, 0x6c,
-	0x11, 0x02, 0x24, 0x90, 0xdc, 0xdd, 0x69, 0x6e, 0xe9, 0x66, 0x6d, 0x6c, 0x6f, 0xa5, 0xbc, 0xf1,
-	0xca, 0xaf, 0xe0, 0x2f, 0xf1, 0x5b, 0xf8, 0x05, 0xc8, 0x5e, 0x3b, 0x64, 0xb3, 0x9b, 0x36, 0x70,
-	0x6f, 0x9e, 0x6f, 0xc6, 0xb3, 0xf6, 0x37, 0xdf, 0x8c, 0x17, 0x86, 0x12, 0x15, 0x2f, 0x65, 0x82,
-	0x13, 0x21, 0xb9, 0xe6, 0x64, 0x20, 0xca, 0xbc, 0x9c, 0x65, 0x52, 0x24, 0xe1, 0x5b, 0x53, 0xce,
-	0xa7, 0x39, 0x9e, 0x5a, 0xc7, 0x45, 0x79, 0x79, 0x8a, 0x33, 0xa1, 0xe7, 0x55, 0x5c, 0xf8, 0xf6,
-	0xaa, 0x53, 0x69, 0x59, 0x26, 0xda, 0x79, 0x87, 0x42, 0xf2, 0xeb, 0x2c, 0x45, 0x59, 0xd9, 0xd1,
-	0x18, 0x46, 0xe7, 0xa5, 0x10, 0x5c, 0x6a, 0xf5, 0x15, 0x32, 0x5d, 0x4a, 0x8c, 0xf1, 0xb7, 0x12,
-	0x95, 0x26, 0x43, 0xe8, 0x64, 0x29, 0x0d, 0x4e, 0x82, 0xf1, 0x20, 0xee, 0x64, 0x69, 0xf4, 0x09,
-	0x1c, 0x35, 0x22, 0x95, 0xe0, 0x85, 0x42, 0x72, 0x0c, 0xf0, 0x8a, 0x29, 0xe7, 0xb5, 0x5b, 0xfa,
-	0xf1, 0x12, 0x12, 0xfd, 0xdd, 0x81, 0x83, 0x18, 0x59, 0x1a, 0xbb, 0x1b, 0xad, 0xf9, 0x04, 0x21,
-	0xb0, 0xa5, 0xe7, 0x02, 0x69, 0xc7, 0x22, 0x76, 0x6d, 0xb0, 0x82, 0xcd, 0x90, 0x76, 0x2b, 0xcc,
-	0xac, 0xc9, 0x08, 0x7a, 0x82, 0x49, 0x2c, 0x34, 0xdd, 0xb2, 0xa8, 0xb3, 0xc8, 0x23, 0x00, 0x21,
-	0xb9, 0x40, 0xa9, 0x33, 0x54, 0xf4, 0xee, 0x49, 0x30, 0xde, 0x39, 0x3b, 0x9a, 0x54, 0x7c, 0x4c,
-	0x3c, 0x1f, 0x93, 0x73, 0xcb, 0x47, 0xbc, 0x14, 0x4a, 0x22, 0xd8, 0x4d, 0x51, 0x60, 0x91, 0x62,
-	0x91, 0x98, 0xad, 0xbd, 0x93, 0xee, 0x78, 0x10, 0xd7, 0x30, 0x12, 0x42, 0xdf, 0x73, 0x47, 0xb7,
-	0xed, 0x67, 0x17, 0x36, 0xa1, 0xb0, 0x7d, 0x8d, 0x52, 0x65, 0xbc, 0xa0, 0x7d, 0xeb, 0xf2, 0x26,
-	0x79, 0x1f, 0xee, 0xb1, 0x24, 0x41, 0xa1, 0xcf, 0x31, 0x91, 0xa8, 0x15, 0x1d, 0x58, 0x76, 0xea,
-	0x20, 0x79, 0x0c, 0x47, 0x2c, 0x4d, 0x33, 0x9d, 0xf1, 0x82, 0xe5, 0x15, 0xf8, 0x4d, 0xa9, 0x45,
-	0xa9, 0x15, 0x05, 0x7b, 0x94, 0x75, 0x6e, 0xf3, 0x65, 0x96, 0x67, 0x4c, 0xa1, 0xa2, 0x3b, 0x36,
-	0xd2, 0x9b, 0x11, 0x83, 0xc3, 0x3a, 0xe7, 0xae, 0x58, 0xfb, 0xd0, 0x2d, 0x65, 0xe1, 0x58, 0x37,
-	0xcb, 0x15, 0xda, 0x3a, 0x1b, 0xd3, 0x16, 0xfd, 0xd5, 0x87, 0xa3, 0x18, 0xa7, 0x99, 0xd2, 0x28,
-	0x57, 0x6b, 0xeb, 0x6b, 0x19, 0xb4, 0xd4, 0xb2, 0xd3, 0x5a, 0xcb, 0x6e, 0xad, 0x96, 0x23, 0xe8,
-	0x25, 0xa5, 0xd2, 0x7c, 0x66, 0x6b, 0xdc, 0x8f, 0x9d, 0x45, 0x4e, 0xa1, 0xc7, 0x2f, 0x7e, 0xc5,
-	0x44, 0xdf, 0x56, 0x5f, 0x17, 0x66, 0x18, 0x32, 0x2e, 0xb3, 0xa3, 0x67, 0x33, 0x79, 0xb3, 0x51,
-	0xf5, 0xed, 0x5b, 0xaa, 0xde, 0x5f, 0xa9, 0xba, 0x80, 0x43, 0x47, 0xc6, 0xfc, 0xe9, 0x72, 0x9e,
-	0xc1, 0x49, 0x77, 0xbc, 0x73, 0xf6, 0xd9, 0x64, 0xd1, 0xb0, 0x93, 0x35, 0x24, 0x4d, 0x5e, 0xb6,
-	0x6c, 0x7f, 0x56, 0x68, 0x39, 0x8f, 0x5b, 0x33, 0x93, 0x07, 0x70, 0x90, 0x62, 0x8e, 0x1a, 0xbf,
-	0xc4, 0x4b, 0x6e, 0x1a, 0x50, 0xe4, 0x2c, 0x41, 0x0a, 0xf6, 0x5e, 0x6d, 0xae, 0x65, 0x65, 0xee,
-	0x34, 0x94, 0x99, 0x4d, 0x0b, 0x2e, 0xf1, 0xc9, 0x2b, 0x56, 0x4c, 0x51, 0xd1, 0x5d, 0x7b, 0xfd,
-	0x3a, 0xd8, 0xd4, 0xef, 0xbd, 0xff, 0xa8, 0xdf, 0xe1, 0xc6, 0xfa, 0xdd, 0xab, 0xe9, 0xd7, 0x30,
-	0x9f, 0xcd, 0xcc, 0xf8, 0x78, 0x9e, 0xd2, 0xfd, 0x8a, 0x79, 0x6f, 0x93, 0x1f, 0x61, 0x58, 0xc9,
-	0xe1, 0xbb, 0x6c, 0x86, 0xdc, 0x7c, 0xe6, 0x0d, 0x2b, 0x86, 0x87, 0x1b, 0x70, 0xfe, 0xa4, 0xb6,
-	0x31, 0x5e, 0x49, 0x44, 0x3e, 0x87, 0xb0, 0x85, 0xc7, 0xa7, 0x78, 0x99, 0x15, 0x98, 0x52, 0x62,
-	0x6f, 0x7f, 0x43, 0x04, 0xf9, 0x18, 0xde, 0x54, 0x6e, 0x4c, 0xbe, 0x64, 0x52, 0x67, 0x2c, 0xff,
-	0x9e, 0xe5, 0x25, 0x2a, 0x7a, 0x60, 0xb7, 0xb6, 0x3b, 0xc3, 0x0f, 0xe1, 0xb0, 0x4d, 0x0b, 0xa6,
-	0x63, 0x4a, 0x59, 0x28, 0x1a, 0x58, 0x6e, 0xec, 0x3a, 0xfc, 0x01, 0x86, 0xf5, 0x3b, 0xd8, 0x5e,
-	0x91, 0xc8, 0xb4, 0xef, 0x36, 0x67, 0x19, 0xbc, 0x14, 0xa9, 0xc1, 0xab, 0x8e, 0x73, 0x96, 0xc1,
-	0xab, 0x1b, 0xf8, 0x9e, 0xab, 0xac, 0xf0, 0xf7, 0x00, 0xee, 0xaf, 0x95, 0xa4, 0x19, 0x1c, 0x57,
-	0x38, 0xf7, 0x83, 0xe3, 0x0a, 0xe7, 0xe4, 0x05, 0xdc, 0xbd, 0x36, 0xe7, 0x77, 0x33, 0xe3, 0xd1,
-	0xff, 0x54, 0x7c, 0x5c, 0x65, 0xf9, 0xb4, 0xf3, 0x38, 0x88, 0xfe, 0x0c, 0x80, 0x36, 0xf7, 0xae,
-	0x1d, 0x5d, 0xd5, 0x0b, 0xd2, 0x59, 0xbc, 0x20, 0xff, 0x4e, 0x87, 0xee, 0x66, 0xd3, 0x61, 0x04,
-	0x3d, 0xa5, 0xd9, 0x45, 0x8e, 0x7e, 0xcc, 0x54, 0x96, 0xd1, 0x65, 0xb5, 0x32, 0xef, 0x88, 0xd5,
-	0xa5, 0x33, 0x23, 0x84, 0xe3, 0xd5, 0x03, 0x3a, 0x31, 0xfb, 0xd1, 0xd7, 0x3c, 0xe6, 0x43, 0xd8,
-	0xe6, 0xae, 0x1f, 0x6e, 0x19, 0xaf, 0x3e, 0xee, 0xec, 0x8f, 0x2d, 0xd8, 0xf3, 0xf9, 0x5f, 0xf0,
-	0x22, 0xd3, 0x5c, 0x92, 0x9f, 0x60, 0x6f, 0xe5, 0x09, 0x26, 0xef, 0x2e, 0x71, 0xde, 0xfe, 0x90,
-	0x87, 0xd1, 0x4d, 0x21, 0x15, 0xb3, 0xd1, 0x1d, 0xf2, 0x05, 0xf4, 0x9e, 0x17, 0xd7, 0xfc, 0x0a,
-	0x09, 0x5d, 0x8a, 0xaf, 0x20, 0x9f, 0xe9, 0x7e, 0x8b, 0x67, 0x91, 0xe0, 0x6b, 0xd8, 0x3d, 0xd7,
-	0x12, 0xd9, 0xec, 0xb5, 0xd2, 0x3c, 0x08, 0xc8, 0xb7, 0xb0, 0xbb, 0xfc, 0x70, 0x91, 0xe3, 0x9a,
-	0xac, 0x1a, 0x7f, 0x11, 0xe1, 0x3b, 0x6b, 0xfd, 0x8b, 0xb3, 0xfd, 0x0c, 0xfb, 0xab, 0x35, 0x23,
-	0xd1, 0xed, 0x6a, 0x0d, 0xdf, 0xbb, 0x31, 0x66, 0x91, 0xfe, 0x97, 0xe6, 0x33, 0xe8, 0xe7, 0xdb,
-	0x07, 0x37, 0x64, 0xa8, 0xcb, 0x26, 0x1c, 0x35, 0x34, 0xf1, 0xcc, 0xfc, 0xd6, 0x45, 0x77, 0x2e,
-	0x7a, 0x16, 0xf9, 0xe8, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x00, 0xa9, 0x5c, 0x60, 0x13, 0x0a,
-	0x00, 0x00,
+	// 888 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xa5, 0x56, 0xdd, 0x4f, 0xd4, 0x40,
+	0x10, 0xe7, 0xee, 0xe0, 0x38, 0x06, 0x38, 0x70, 0x39, 0xa1, 0x54, 0x83, 0x58, 0x8d, 0x41, 0x1f,
+	0x8e, 0x0f, 0x4d, 0x40, 0x63, 0x34, 0x11, 0xd0, 0xf0, 0x40, 0xc4, 0x62, 0x8c, 0x9a, 0x68, 0xb2,
+	0xd7, 0x0e, 0x47, 0xa5, 0xd7, 0xd6, 0xed, 0x96, 0xe4, 0xde, 0x7c, 0xf5, 0xaf, 0xf0, 0x7f, 0xf4,
+	0xc9, 0x47, 0x77, 0xb7, 0xed, 0x79, 0xfd, 0x38, 0x40, 0x7d, 0xea, 0xce, 0xc7, 0xce, 0xee, 0xfc,
+	0xe6, 0x37, 0xb3, 0x85, 0x26, 0xc3, 0xd0, 0x8f, 0x98, 0x85, 0xed, 0x80, 0xf9, 0xdc, 0x27, 0x53,
+	0x41, 0xe4, 0x46, 0x3d, 0x87, 0x05, 0x96, 0x7e, 0xa3, 0xeb, 0xfb, 0x5d, 0x17, 0xd7, 0x95, 0xa1,
+	0x13, 0x9d, 0xac, 0x63, 0x2f, 0xe0, 0xfd, 0xd8, 0x4f, 0xbf, 0x99, 0x37, 0x86, 0x9c, 0x45, 0x16,
+	0x4f, 0xac, 0x4d, 0xf1, 0x39, 0x77, 0x6c, 0x64, 0xb1, 0x6c, 0xac, 0xc1, 0xe2, 0x71, 0x14, 0x04,
+	0x3e, 0xe3, 0xe1, 0x4b, 0xa4, 0x3c, 0x62, 0x68, 0xe2, 0xd7, 0x08, 0x43, 0x4e, 0x9a, 0x50, 0x75,
+	0x6c, 0xad, 0xb2, 0x5a, 0x59, 0x9b, 0x32, 0xc5, 0xca, 0x78, 0x0c, 0x4b, 0x05, 0xcf, 0x30, 0xf0,
+	0xbd, 0x10, 0xc9, 0x0a, 0xc0, 0x29, 0x0d, 0x13, 0xab, 0xda, 0xd2, 0x30, 0x87, 0x34, 0xc6, 0xcf,
+	0x2a, 0x2c, 0x98, 0x48, 0x6d, 0x33, 0xc9, 0x68, 0xc4, 0x11, 0x84, 0xc0, 0x38, 0xef, 0x07, 0xa8,
+	0x55, 0x95, 0x46, 0xad, 0xa5, 0xce, 0xa3, 0x3d, 0xd4, 0x6a, 0xb1, 0x4e, 0xae, 0xc9, 0x22, 0xd4,
+	0x03, 0xca, 0xd0, 0xe3, 0xda, 0xb8, 0xd2, 0x26, 0x12, 0xd9, 0x06, 0x10, 0x59, 0x05, 0xc8, 0xb8,
+	0x83, 0xa1, 0x36, 0x21, 0x6c, 0xd3, 0x5b, 0x4b, 0xed, 0x18, 0x8f, 0x76, 0x8a, 0x47, 0xfb, 0x58,
+	0xe1, 0x61, 0x0e, 0xb9, 0x12, 0x03, 0x66, 0x6c, 0x0c, 0xd0, 0xb3, 0xd1, 0xb3, 0xe4, 0xd6, 0xfa,
+	0x6a, 0x4d, 0x84, 0xcd, 0xe8, 0x88, 0x0e, 0x8d, 0x14, 0x3b, 0x6d, 0x52, 0x1d, 0x3b, 0x90, 0x89,
+	0x06, 0x93, 0xe7, 0xc8, 0x42, 0xc7, 0xf7, 0xb4, 0x86, 0x32, 0xa5, 0x22, 0xb9, 0x0b, 0xb3, 0xd4,
+	0xb2, 0x30, 0xe0, 0xc7, 0x68, 0x31, 0xe4, 0xa1, 0x36, 0xa5, 0xd0, 0xc9, 0x2a, 0xc9, 0x0e, 0x2c,
+	0x51, 0xdb, 0x76, 0xb8, 0xd8, 0x41, 0xdd, 0x58, 0xf9, 0x3a, 0xe2, 0x41, 0x24, 0xfc, 0x41, 0x5d,
+	0x65, 0x94, 0x59, 0x9e, 0x4c, 0x5d, 0x87, 0x86, 0xe2, 0xd2, 0xd3, 0xca, 0x33, 0x15, 0x0d, 0x0a,
+	0xad, 0x2c, 0xe6, 0x49, 0xb1, 0xe6, 0xa1, 0x16, 0x31, 0x2f, 0x41, 0x5d, 0x2e, 0x73, 0xb0, 0x55,
+	0xaf, 0x0c, 0x9b, 0xf1, 0xab, 0x01, 0x4b, 0x26, 0x76, 0x9d, 0x90, 0x23, 0xcb, 0xd7, 0x36, 0xad,
+	0x65, 0xa5, 0xa4, 0x96, 0xd5, 0xd2, 0x5a, 0xd6, 0x32, 0xb5, 0x14, 0x7a, 0x2b, 0x0a, 0xb9, 0xdf,
+	0x53, 0x35, 0x6e, 0x98, 0x89, 0x44, 0xd6, 0xa1, 0xee, 0x77, 0xbe, 0xa0, 0xc5, 0x2f, 0xab, 0x6f,
+	0xe2, 0x26, 0x11, 0x92, 0x26, 0xb9, 0xa3, 0xae, 0x22, 0xa5, 0x62, 0xa1, 0xea, 0x93, 0x97, 0x54,
+	0xbd, 0x91, 0xab, 0x7a, 0x00, 0xad, 0x04, 0x8c, 0xfe, 0xde, 0x70, 0x9c, 0x29, 0x11, 0x67, 0x7a,
+	0xeb, 0x69, 0x7b, 0xd0, 0xb0, 0xed, 0x11, 0x20, 0xb5, 0x8f, 0x4a, 0xb6, 0xef, 0x7b, 0x9c, 0xf5,
+	0xcd, 0xd2, 0xc8, 0x64, 0x03, 0x16, 0x6c, 0x74, 0x91, 0xe3, 0x0b, 0x3c, 0xf1, 0x65, 0x03, 0x06,
+	0x2e, 0xb5, 0x50, 0x70, 0x44, 0xe6, 0x55, 0x66, 0x1a, 0x66, 0xe6, 0x74, 0x81, 0x99, 0x4e, 0xd7,
+	0x13, 0xae, 0xbb, 0xa7, 0xd4, 0xeb, 0x8a, 0x6b, 0xcf, 0xa8, 0xf4, 0xb3, 0xca, 0x22, 0x7f, 0x67,
+	0xff, 0x92, 0xbf, 0xcd, 0x2b, 0xf3, 0x77, 0x2e, 0xc3, 0x5f, 0x89, 0xbc, 0xd3, 0x93, 0xe3, 0xe3,
+	0xc0, 0xd6, 0xe6, 0x63, 0xe4, 0x53, 0x99, 0x7c, 0x80, 0x66, 0x4c, 0x87, 0xb7, 0x4e, 0x0f, 0x7d,
+	0x79, 0xcc, 0x35, 0x45, 0x86, 0xcd, 0x2b, 0x60, 0xbe, 0x9b, 0xd9, 0x68, 0xe6, 0x02, 0x91, 0x67,
+	0xa0, 0x97, 0xe0, 0xb8, 0x87, 0x27, 0x8e, 0x87, 0xb6, 0x46, 0x54, 0xf6, 0x17, 0x78, 0x90, 0x47,
+	0x70, 0x3d, 0x4c, 0xc6, 0xe4, 0x11, 0x15, 0x6d, 0x42, 0xdd, 0x77, 0xd4, 0x15, 0x07, 0x6b, 0x0b,
+	0x6a, 0x6b, 0xb9, 0x91, 0xdc, 0x03, 0x31, 0xee, 0x39, 0x75, 0xbc, 0xd7, 0xde, 0x9e, 0x8a, 0xad,
+	0xb5, 0x94, 0x7b, 0x4e, 0xab, 0x3f, 0x80, 0x56, 0x19, 0x67, 0x64, 0x67, 0x89, 0x4e, 0x0e, 0x45,
+	0xb7, 0x49, 0x0c, 0xd5, 0x5a, 0x7f, 0x0f, 0xcd, 0x6c, 0xae, 0xaa, 0xa7, 0x98, 0x98, 0xdd, 0x69,
+	0x57, 0x26, 0x92, 0xd4, 0x47, 0x81, 0x2d, 0xf5, 0x71, 0x67, 0x26, 0x92, 0xd4, 0xc7, 0x99, 0xa6,
+	0xbd, 0x19, 0x4b, 0xfa, 0xb7, 0x0a, 0x2c, 0x8f, 0xa4, 0xae, 0x1c, 0x30, 0x67, 0xd8, 0x4f, 0x07,
+	0x8c, 0x58, 0x92, 0x43, 0x98, 0x38, 0x97, 0x79, 0x26, 0xb3, 0x65, 0xfb, 0x1f, 0x3b, 0xc3, 0x8c,
+	0xa3, 0x3c, 0xa9, 0xee, 0x54, 0x8c, 0x1f, 0x15, 0xd0, 0x8a, 0x7b, 0x47, 0x8e, 0xb8, 0xf8, 0xa5,
+	0xa9, 0x0e, 0x5e, 0x9a, 0x3f, 0x53, 0xa4, 0x76, 0xb5, 0x29, 0x22, 0xa0, 0x08, 0x39, 0xed, 0xb8,
+	0x98, 0x8e, 0xa3, 0x58, 0x92, 0xfc, 0x8d, 0x57, 0xf2, 0xbd, 0x51, 0xfc, 0x4d, 0x44, 0x03, 0x61,
+	0x25, 0x7f, 0xc1, 0x84, 0xf4, 0xe9, 0x88, 0x2c, 0x5e, 0x73, 0x13, 0x26, 0xfd, 0xa4, 0x6f, 0x2e,
+	0x19, 0xc3, 0xa9, 0xdf, 0xd6, 0xf7, 0x71, 0x98, 0x4b, 0xe3, 0x1f, 0xfa, 0x9e, 0xc3, 0x7d, 0x46,
+	0x3e, 0xc2, 0x5c, 0xee, 0xa9, 0x26, 0xb7, 0x87, 0x30, 0x2f, 0x7f, 0xf0, 0x75, 0xe3, 0x22, 0x97,
+	0x18, 0x59, 0x63, 0x8c, 0x3c, 0x87, 0xfa, 0x81, 0x77, 0xee, 0x9f, 0x89, 0xd4, 0x87, 0xfc, 0x63,
+	0x55, 0x1a, 0x69, 0xb9, 0xc4, 0x32, 0x08, 0xf0, 0x0a, 0x66, 0x44, 0x0e, 0x48, 0x7b, 0xff, 0x15,
+	0x66, 0xa3, 0x42, 0xde, 0xc0, 0xcc, 0xf0, 0x03, 0x47, 0x56, 0x32, 0xb4, 0x2a, 0xfc, 0x6d, 0xe8,
+	0xb7, 0x46, 0xda, 0x07, 0x77, 0xfb, 0x04, 0xf3, 0xf9, 0x9a, 0x11, 0xe3, 0x72, 0xb6, 0xea, 0x77,
+	0x2e, 0xf4, 0x19, 0x84, 0xff, 0x5c, 0x7c, 0x2e, 0xd3, 0x39, 0x78, 0xff, 0x82, 0x08, 0x59, 0xda,
+	0xe8, 0x8b, 0x05, 0x4e, 0xec, 0xcb, 0xdf, 0x3f, 0x63, 0xac, 0x53, 0x57, 0x9a, 0x87, 0xbf, 0x01,
+	0xd2, 0xaf, 0x59, 0x85, 0x3b, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -312,6 +312,12 @@ class ResourceOptions:
     If provided and True, this resource must be deleted before it is replaced.
     """
 
+    retain_on_delete: Optional[bool]
+    """
+    If provided and True, deleting this resource only removes it from the stack; the resource's provider is not
+    asked to delete the underlying cloud resource.
+    """
+
     provider: Optional['ProviderResource']
     """
     An optional provider to use for this resource's CRUD operations. If no provider is supplied, the
@@ -388,7 +394,8 @@ class ResourceOptions:
                  id: Optional['Input[str]'] = None,
                  import_: Optional[str] = None,
                  custom_timeouts: Optional['CustomTimeouts'] = None,
-                 transformations: Optional[List[ResourceTransformation]] = None) -> None:
+                 transformations: Optional[List[ResourceTransformation]] = None,
+                 retain_on_delete: Optional[bool] = None) -> None:
         """
         :param Optional[Resource] parent: If provided, the currently-constructing resource should be the child of
                the provided parent resource.
@@ -413,6 +420,8 @@ class ResourceOptions:
         :param Optional[CustomTimeouts] customTimeouts: If provided, a config block for custom timeout information.
         :param Optional[transformations] transformations: If provided, a list of transformations to apply to this resource
                during construction.
+        :param Optional[bool] retain_on_delete: If provided and True, deleting this resource only removes it from the stack;
+               the resource's provider is not asked to delete the underlying cloud resource.
         """
 
         # Expose 'merge' again this this object, but this time as an instance method.
@@ -434,6 +443,7 @@ class ResourceOptions:
         self.id = id
         self.import_ = import_
         self.transformations = transformations
+        self.retain_on_delete = retain_on_delete
 
         if depends_on is not None:
             for dep in depends_on:
@@ -504,6 +514,7 @@ class ResourceOptions:
         dest.custom_timeouts = dest.custom_timeouts if source.custom_timeouts is None else source.custom_timeouts
         dest.id = dest.id if source.id is None else source.id
         dest.import_ = dest.import_ if source.import_ is None else source.import_
+        dest.retain_on_delete = dest.retain_on_delete if source.retain_on_delete is None else source.retain_on_delete
 
         # Now, if we are left with a .providers that is just a single key/value pair, then
         # collapse that down into .provider form.
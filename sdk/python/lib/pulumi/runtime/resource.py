@@ -391,6 +391,7 @@ def _register_resource(res: 'Resource',
                 custom=custom,
                 object=resolver.serialized_props,
                 protect=opts.protect,
+                retainOnDelete=bool(opts.retain_on_delete),
                 provider=resolver.provider_ref,
                 dependencies=resolver.dependencies,
                 propertyDependencies=property_dependencies,